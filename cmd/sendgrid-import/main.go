@@ -0,0 +1,73 @@
+// Command sendgrid-import generates the Terraform configuration adopting existing transactional templates:
+// the import blocks of Terraform 1.5 and the matching resources of the templates and all their versions.
+//
+// Usage:
+//
+//	SENDGRID_API_KEY=... go run ./cmd/sendgrid-import -content-dir templates [-on-behalf-of subuser] [templateID...] > imports.tf
+//
+// Without template IDs, all the templates of the account are imported.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/importgen"
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+)
+
+// errAPIKeyRequired error displayed when the SENDGRID_API_KEY environment variable isn't set.
+var errAPIKeyRequired = errors.New("the SENDGRID_API_KEY environment variable is required")
+
+func main() {
+	var (
+		opts       importgen.Options
+		onBehalfOf string
+		host       string
+		output     string
+	)
+
+	flag.StringVar(&opts.ContentDir, "content-dir", "",
+		"directory where the contents of the versions are written, inlined in the configuration when empty")
+	flag.StringVar(&onBehalfOf, "on-behalf-of", os.Getenv("SENDGRID_SUBUSER"), "subuser owning the templates")
+	flag.StringVar(&host, "host", os.Getenv("SENDGRID_HOST"), "SendGrid API host")
+	flag.StringVar(&output, "o", "", "file where the configuration is written, the standard output when empty")
+	flag.Parse()
+
+	if err := run(opts, onBehalfOf, host, output, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "sendgrid-import:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts importgen.Options, onBehalfOf, host, output string, ids []string) error {
+	apiKey := os.Getenv("SENDGRID_API_KEY")
+	if apiKey == "" {
+		return errAPIKeyRequired
+	}
+
+	ctx := context.Background()
+	c := sendgrid.NewClient(apiKey, host, onBehalfOf)
+
+	templates, err := importgen.ReadTemplates(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	return importgen.Generate(w, templates, opts)
+}
@@ -0,0 +1,26 @@
+# All the API keys of the account, including the ones created outside Terraform
+data "sendgrid_api_keys" "all" {}
+
+# The keys able to send emails
+data "sendgrid_api_keys" "mail_send" {
+  scope = "mail.send"
+}
+
+# The keys of each subuser, for a security review
+variable "subusers" {
+  type = set(string)
+}
+
+data "sendgrid_api_keys" "subusers" {
+  for_each              = var.subusers
+  sub_user_on_behalf_of = each.key
+}
+
+output "api_key_inventory" {
+  value = merge(
+    { for k in data.sendgrid_api_keys.all.api_keys : k.id => { owner = "account", name = k.name, scopes = k.scopes } },
+    [for subuser, keys in data.sendgrid_api_keys.subusers : {
+      for k in keys.api_keys : k.id => { owner = subuser, name = k.name, scopes = k.scopes }
+    }]...
+  )
+}
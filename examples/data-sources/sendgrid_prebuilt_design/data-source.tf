@@ -0,0 +1,7 @@
+data "sendgrid_prebuilt_design" "ingrid" {
+  name = "Ingrid"
+}
+
+output "ingrid_thumbnail" {
+  value = data.sendgrid_prebuilt_design.ingrid.thumbnail_url
+}
@@ -0,0 +1,19 @@
+# Render the version with its own test_data
+data "sendgrid_template_render" "welcome" {
+  template_id = sendgrid_template.welcome.id
+  version_id  = sendgrid_template_version.welcome.id
+}
+
+# Render local files without any API call, e.g. for snapshot tests in CI
+data "sendgrid_template_render" "welcome_vip" {
+  subject      = "Welcome {{first_name}}"
+  html_content = file("${path.module}/templates/welcome.html")
+  test_data = jsonencode({
+    first_name = "Ada"
+    vip        = true
+  })
+}
+
+output "welcome_vip_html" {
+  value = data.sendgrid_template_render.welcome_vip.rendered_html
+}
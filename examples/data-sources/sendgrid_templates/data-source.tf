@@ -0,0 +1,20 @@
+# All the dynamic templates of an environment, keyed by name for for_each
+data "sendgrid_templates" "production" {
+  generation = "dynamic"
+  name_regex = "^prod-"
+}
+
+locals {
+  production_templates = { for t in data.sendgrid_templates.production.templates : t.name => t }
+}
+
+# Templates not updated for a year, to audit them
+data "sendgrid_templates" "recent" {
+  updated_after = timeadd(plantimestamp(), "-8760h")
+}
+
+data "sendgrid_templates" "all" {}
+
+output "stale_template_ids" {
+  value = setsubtract(data.sendgrid_templates.all.ids, data.sendgrid_templates.recent.ids)
+}
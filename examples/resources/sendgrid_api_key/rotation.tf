@@ -0,0 +1,21 @@
+# Rotated every 30 days, the services have 24 hours to switch to the new key
+resource "sendgrid_api_key" "rotated" {
+  name   = "my-app-api-key"
+  scopes = ["mail.send"]
+
+  rotation {
+    rotate_after = "720h"
+    grace_period = "24h"
+
+    # Changing a keeper rotates the key at once, e.g. after a leak
+    keepers = {
+      generation = "1"
+    }
+  }
+}
+
+# Both keys are valid during the grace period
+output "api_keys" {
+  value     = compact([sendgrid_api_key.rotated.api_key, sendgrid_api_key.rotated.previous_api_key])
+  sensitive = true
+}
@@ -0,0 +1,13 @@
+# API key for a deployment pipeline: everything but billing, plus the template scopes spelled out by a pattern.
+# The plan lists every scope the presets and patterns expand to.
+resource "sendgrid_api_key" "deploy" {
+  name           = "deploy-key"
+  scope_presets  = ["full_access_minus_billing"]
+  scope_patterns = ["templates.*"]
+}
+
+# Read-only key for monitoring
+resource "sendgrid_api_key" "monitoring" {
+  name          = "monitoring-readonly-key"
+  scope_presets = ["read_only"]
+}
@@ -0,0 +1,29 @@
+# The key is never stored in the state: it is written to a file readable by its owner only
+resource "sendgrid_api_key" "file" {
+  name   = "my-app-api-key"
+  scopes = ["mail.send"]
+
+  sink {
+    file = "${path.module}/secrets/sendgrid_api_key"
+  }
+}
+
+# Or handed to a secret manager on the standard input of a command, at creation and at each rotation
+resource "sendgrid_api_key" "vault" {
+  name   = "my-app-api-key"
+  scopes = ["mail.send"]
+
+  sink {
+    command = "vault kv put secret/sendgrid/$SENDGRID_API_KEY_NAME api_key=-"
+  }
+
+  rotation {
+    rotate_after = "720h"
+    grace_period = "24h"
+  }
+}
+
+# The hash changes with each new key, e.g. to restart the services reading it
+output "api_key_sha256" {
+  value = sendgrid_api_key.vault.api_key_sha256
+}
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Import an existing design of the Design Library using its ID
+terraform import sendgrid_design.newsletter 3247eaea-c912-42b2-b0b6-4a1bcd7cd8cf
@@ -0,0 +1,18 @@
+# A vetted building block shared by marketing campaigns and transactional templates
+resource "sendgrid_design" "newsletter" {
+  name         = "Newsletter"
+  subject      = "News from {{company_name}}"
+  html_content = file("${path.module}/designs/newsletter.html")
+  categories   = ["newsletter", "approved"]
+}
+
+# Start a design from a pre-built design of SendGrid
+data "sendgrid_prebuilt_design" "ingrid" {
+  name = "Ingrid"
+}
+
+resource "sendgrid_design" "announcement" {
+  name         = "Announcement"
+  editor       = data.sendgrid_prebuilt_design.ingrid.editor
+  html_content = data.sendgrid_prebuilt_design.ingrid.html_content
+}
@@ -0,0 +1,8 @@
+# Duplicate a design into a transactional template version.
+# The design is read at plan time, edits made in the Design Library show up in the plan.
+resource "sendgrid_template_version" "newsletter" {
+  name        = "Newsletter"
+  template_id = sendgrid_template.newsletter.id
+  subject     = "News from {{company_name}}"
+  design_id   = sendgrid_design.newsletter.id
+}
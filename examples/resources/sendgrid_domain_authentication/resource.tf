@@ -15,3 +15,28 @@ resource "sendgrid_domain_authentication" "marketing" {
   automatic_security = false
   custom_spf         = true
 }
+
+# Wait for the DNS records to be published before reporting the domain as valid
+resource "sendgrid_domain_authentication" "transactional" {
+  domain             = "transactional.mycompany.com"
+  automatic_security = true
+
+  wait_for_validation {
+    timeout  = "15m"
+    interval = "30s"
+  }
+}
+
+# Publish the records in Route53. The roles only depend on automatic_security:
+# mail_cname, dkim1 and dkim2 with automatic security, mail_server, subdomain_spf
+# and dkim with manual security. They can be used as for_each keys before the
+# domain is created.
+resource "aws_route53_record" "sendgrid" {
+  for_each = toset(["mail_cname", "dkim1", "dkim2"])
+
+  zone_id = var.route53_zone_id
+  name    = sendgrid_domain_authentication.transactional.dns_record_hosts[each.key]
+  type    = sendgrid_domain_authentication.transactional.dns_record_types[each.key]
+  ttl     = 300
+  records = [sendgrid_domain_authentication.transactional.dns_record_data[each.key]]
+}
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Import a domain authentication association using the domain ID and the subuser username
+terraform import sendgrid_domain_authentication_subuser.customer 12345/customer-subuser
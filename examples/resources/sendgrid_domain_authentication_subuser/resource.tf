@@ -0,0 +1,12 @@
+# Share an authenticated domain of the parent account with a subuser
+resource "sendgrid_domain_authentication_subuser" "customer" {
+  domain_authentication_id = sendgrid_domain_authentication.main.id
+  username                 = sendgrid_subuser.customer.username
+}
+
+# Allow a subuser to have up to five authenticated domains
+resource "sendgrid_domain_authentication_subuser" "marketing" {
+  domain_authentication_id = sendgrid_domain_authentication.marketing.id
+  username                 = sendgrid_subuser.customer.username
+  multiple                 = true
+}
@@ -11,3 +11,12 @@ resource "sendgrid_link_branding" "marketing" {
   subdomain  = "track"
   is_default = false
 }
+
+# Wait for the DNS records to be published before reporting the link branding as valid
+resource "sendgrid_link_branding" "transactional" {
+  domain = "transactional.mycompany.com"
+
+  wait_for_validation {
+    timeout = "15m"
+  }
+}
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Import a link branding association using the link branding ID and the subuser username
+terraform import sendgrid_link_branding_subuser.customer 12345/customer-subuser
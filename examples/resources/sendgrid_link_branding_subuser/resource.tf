@@ -0,0 +1,5 @@
+# Share a branded link of the parent account with a subuser
+resource "sendgrid_link_branding_subuser" "customer" {
+  link_branding_id = sendgrid_link_branding.main.id
+  username         = sendgrid_subuser.customer.username
+}
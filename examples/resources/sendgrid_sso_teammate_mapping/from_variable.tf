@@ -0,0 +1,19 @@
+variable "idp_users" {
+  type = list(object({
+    email      = string
+    first_name = string
+    last_name  = string
+    groups     = list(string)
+  }))
+}
+
+resource "sendgrid_sso_teammate_mapping" "from_variable" {
+  users = jsonencode(var.idp_users)
+
+  group_rule {
+    group  = "sendgrid-billing"
+    scopes = ["billing.read"]
+  }
+
+  batch_size = 10
+}
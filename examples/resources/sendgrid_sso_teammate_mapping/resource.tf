@@ -0,0 +1,26 @@
+# Users exported from the identity provider, e.g. okta_users.csv:
+#
+#   email,first_name,last_name,groups
+#   ada@example.com,Ada,Lovelace,sendgrid-developers;sendgrid-support
+#   grace@example.com,Grace,Hopper,sendgrid-admins
+resource "sendgrid_sso_teammate_mapping" "okta" {
+  users_file = "${path.module}/okta_users.csv"
+
+  group_rule {
+    group         = "sendgrid-developers"
+    scope_presets = ["mail_send_only"]
+    scope_patterns = [
+      "templates.*",
+    ]
+  }
+
+  group_rule {
+    group         = "sendgrid-support"
+    scope_presets = ["read_only"]
+  }
+
+  group_rule {
+    group    = "sendgrid-admins"
+    is_admin = true
+  }
+}
@@ -14,3 +14,23 @@ resource "sendgrid_subuser" "staging_subuser" {
   ips      = ["192.168.1.101"]
   disabled = true
 }
+
+# Reseller customer with a monthly credit cap and a monitor
+resource "sendgrid_subuser" "customer_subuser" {
+  username                = "customer-acme"
+  email                   = "acme@mycompany.com"
+  password                = "CustomerPass789!"
+  ips                     = ["192.168.1.102"]
+  website_access_disabled = true
+
+  credit_allocation {
+    type            = "recurring"
+    reset_frequency = "monthly"
+    total           = 50000
+  }
+
+  monitor {
+    email     = "deliverability@mycompany.com"
+    frequency = 1000
+  }
+}
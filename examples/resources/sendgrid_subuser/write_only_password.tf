@@ -0,0 +1,25 @@
+# Subuser whose password is never stored in the state (requires Terraform 1.11+)
+variable "subuser_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+variable "subuser_previous_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+  default   = null
+}
+
+resource "sendgrid_subuser" "rotated_subuser" {
+  username    = "rotated-app"
+  email       = "rotated-app@mycompany.com"
+  password_wo = var.subuser_password
+  ips         = ["192.168.1.103"]
+
+  # To rotate the password, set subuser_password to the new value,
+  # subuser_previous_password to the current one, and bump password_version.
+  previous_password_wo = var.subuser_previous_password
+  password_version     = 1
+}
@@ -0,0 +1,22 @@
+# The invitation is resent when it expires within 48 hours,
+# and the apply waits up to an hour for the teammate to accept it.
+resource "sendgrid_teammate" "on_call" {
+  email    = "on.call@example.com"
+  is_admin = false
+  is_sso   = false
+  scopes = [
+    "mail.send",
+    "stats.read"
+  ]
+
+  resend_invite_before = "48h"
+  wait_for_accept      = true
+
+  timeouts {
+    create = "1h"
+  }
+}
+
+output "invite_expiration_date" {
+  value = sendgrid_teammate.on_call.expiration_date
+}
@@ -0,0 +1,9 @@
+# Teammate managing the settings of the emails, without writing out every scope
+resource "sendgrid_teammate" "deliverability" {
+  email    = "deliverability@example.com"
+  is_admin = false
+  is_sso   = false
+
+  scopes         = ["stats.read"]
+  scope_patterns = ["mail_settings.*", "tracking_settings.*"]
+}
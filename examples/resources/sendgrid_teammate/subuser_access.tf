@@ -0,0 +1,19 @@
+# Support teammate restricted to the subusers of two customers
+resource "sendgrid_teammate" "support" {
+  email      = "support@example.com"
+  first_name = "Jane"
+  last_name  = "Doe"
+  is_admin   = false
+  is_sso     = true
+
+  subuser_access {
+    subuser         = "customer-a"
+    permission_type = "restricted"
+    scopes          = ["stats.read", "suppression.read", "suppression.delete"]
+  }
+
+  subuser_access {
+    subuser         = "customer-b"
+    permission_type = "admin"
+  }
+}
@@ -0,0 +1,53 @@
+# The teammates of the team, by email
+variable "team" {
+  type = map(object({
+    is_admin   = optional(bool, false)
+    scopes     = optional(list(string), [])
+    sso        = optional(bool, false)
+    first_name = optional(string)
+    last_name  = optional(string)
+  }))
+  default = {
+    "dev1@example.com" = {
+      scopes = ["mail.send", "templates.read"]
+    }
+    "dev2@example.com" = {
+      scopes = ["mail.send", "templates.read", "stats.read"]
+    }
+    "lead@example.com" = {
+      is_admin = true
+    }
+    "support@example.com" = {
+      sso        = true
+      first_name = "Support"
+      last_name  = "Team"
+      scopes     = ["stats.read"]
+    }
+  }
+}
+
+resource "sendgrid_teammates" "team" {
+  dynamic "teammate" {
+    for_each = var.team
+
+    content {
+      email      = teammate.key
+      is_admin   = teammate.value.is_admin
+      is_sso     = teammate.value.sso
+      first_name = teammate.value.first_name
+      last_name  = teammate.value.last_name
+      scopes     = teammate.value.scopes
+    }
+  }
+
+  batch_size = 5
+
+  timeouts {
+    create = "45m"
+    update = "45m"
+  }
+}
+
+output "pending_teammates" {
+  value = [for email, status in sendgrid_teammates.team.status : email if status == "pending"]
+}
@@ -0,0 +1,5 @@
+#!/bin/bash
+
+# Import the active version of an existing template using its template_id
+# version_id is set to the version which is currently active
+terraform import sendgrid_template_active_version.welcome d-template-id
@@ -0,0 +1,27 @@
+variable "welcome_color" {
+  type    = string
+  default = "blue"
+}
+
+resource "sendgrid_template" "welcome" {
+  name       = "welcome"
+  generation = "dynamic"
+}
+
+# Don't set active on versions managed by sendgrid_template_active_version
+resource "sendgrid_template_version" "welcome" {
+  for_each = toset(["blue", "green"])
+
+  name              = "welcome-${each.key}"
+  template_id       = sendgrid_template.welcome.id
+  subject           = "Welcome {{first_name}}"
+  html_content_file = "${path.module}/templates/welcome-${each.key}.html"
+}
+
+# Switch welcome_color to deploy the other version, set rollback = true
+# to activate the previously active one again.
+resource "sendgrid_template_active_version" "welcome" {
+  template_id = sendgrid_template.welcome.id
+  version_id  = sendgrid_template_version.welcome[var.welcome_color].id
+  rollback    = false
+}
@@ -0,0 +1,19 @@
+# Promote the templates of the staging subuser to the production subuser
+resource "sendgrid_template_copy" "welcome" {
+  source_template_id  = var.staging_welcome_template_id
+  source_on_behalf_of = "staging"
+  target_on_behalf_of = "production"
+}
+
+# The production template IDs, e.g. for the configuration of the application
+output "welcome_template_id" {
+  value = sendgrid_template_copy.welcome.id
+}
+
+# Copy a template from another account once, without following its later changes
+resource "sendgrid_template_copy" "receipt" {
+  source_template_id = "d-0123456789abcdef0123456789abcdef"
+  source_api_key_wo  = var.other_account_api_key
+  name               = "Receipt"
+  keep_in_sync       = false
+}
@@ -0,0 +1,23 @@
+# Send the new version to the seed list each time its content changes
+resource "sendgrid_template_test_send" "welcome_v2" {
+  template_id = sendgrid_template.welcome.id
+  version_id  = sendgrid_template_version.welcome_v2.id
+  to          = ["qa@example.com", "seed-gmail@example.com", "seed-outlook@example.com"]
+  from_email  = "no-reply@example.com"
+  categories  = ["test-send"]
+
+  test_data = jsonencode({
+    first_name = "Ada"
+  })
+
+  triggers = {
+    content = sendgrid_template_version.welcome_v2.content_sha256
+  }
+}
+
+# Only activate the version once it has been checked, e.g. with a variable set after the human
+# or inbox placement check of the seeds
+resource "sendgrid_template_active_version" "welcome" {
+  template_id = sendgrid_template.welcome.id
+  version_id  = var.welcome_v2_approved ? sendgrid_template_test_send.welcome_v2.sent_version_id : sendgrid_template_version.welcome_v1.id
+}
@@ -0,0 +1,11 @@
+# Template version loaded from files kept in git.
+# Only content_sha256 is stored in the state, so plans show a hash change
+# instead of the whole HTML, and edits made in the SendGrid UI are detected.
+resource "sendgrid_template_version" "welcome_from_files" {
+  name                   = "Welcome Email from files"
+  template_id            = sendgrid_template.welcome_email.id
+  subject                = "Welcome to {{company_name}}!"
+  html_content_file      = "${path.module}/templates/welcome.html"
+  plain_content_file     = "${path.module}/templates/welcome.txt"
+  generate_plain_content = false
+}
@@ -0,0 +1,8 @@
+# A version edited in the Design Library: the HTML content is read from SendGrid
+# and the plain content is generated from it by the provider.
+resource "sendgrid_template_version" "newsletter" {
+  name        = "Newsletter"
+  template_id = sendgrid_template.welcome_email.id
+  subject     = "News from {{company_name}}"
+  editor      = "design"
+}
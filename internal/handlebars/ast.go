@@ -0,0 +1,115 @@
+// Package handlebars parses, validates and renders the Handlebars content of SendGrid dynamic templates.
+package handlebars
+
+// Pos is a position in the template source, lines and columns start at 1.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+// Node is a node of a parsed template.
+type Node interface {
+	Position() Pos
+}
+
+// Expr is an argument of a helper call: a *Path, a *Literal or a *SubExpr.
+type Expr interface {
+	Position() Pos
+}
+
+// Text is raw content outside of mustaches.
+type Text struct {
+	Pos
+	Value string
+}
+
+// Mustache is a {{expression}}, HTML escaped unless written {{{expression}}} or {{&expression}}.
+type Mustache struct {
+	Pos
+	Call
+	Escaped bool
+}
+
+// Block is a {{#helper}}...{{else}}...{{/helper}} or an inverted {{^path}}...{{/path}} section.
+type Block struct {
+	Pos
+	Call
+	Program  []Node
+	Inverse  []Node
+	Inverted bool
+	// Chained is true for the blocks opened by {{else helper}}, they are closed by their parent.
+	Chained bool
+}
+
+// Comment is a {{! comment}} or a {{!-- comment --}}.
+type Comment struct {
+	Pos
+	Value string
+}
+
+// Partial is a {{> partial}}, they are not supported by SendGrid.
+type Partial struct {
+	Pos
+	Name string
+}
+
+// Call is a helper call or a path lookup when there are no arguments.
+type Call struct {
+	Path   *Path
+	Params []Expr
+	Hash   []*HashPair
+}
+
+// HashPair is a key=value argument.
+type HashPair struct {
+	Pos
+	Key   string
+	Value Expr
+}
+
+// Path is a lookup in the context, e.g. name, ../name, this.items.[0] or @index.
+type Path struct {
+	Pos
+	Original string
+	Parts    []string
+	// Depth is the number of ../ segments.
+	Depth int
+	// Data is true for the @ variables set by the helpers, e.g. @index or @root.
+	Data bool
+}
+
+// Literal is a string, number, boolean or null argument.
+type Literal struct {
+	Pos
+	Value interface{}
+}
+
+// SubExpr is a (helper arguments) argument.
+type SubExpr struct {
+	Pos
+	Call
+}
+
+// Position returns the position of the node in the source.
+func (p Pos) Position() Pos {
+	return p
+}
+
+// IsHelperCall reports whether the call has arguments, and can't be a simple lookup.
+func (c *Call) IsHelperCall() bool {
+	return len(c.Params) > 0 || len(c.Hash) > 0
+}
+
+// Name returns the name of the helper or the path being looked up.
+func (c *Call) Name() string {
+	if c.Path == nil {
+		return ""
+	}
+
+	return c.Path.Original
+}
+
+// Template is a parsed template.
+type Template struct {
+	Nodes []Node
+}
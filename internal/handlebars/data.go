@@ -0,0 +1,93 @@
+package handlebars
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Object is a JSON object which keeps the order of its keys, so that {{#each}} iterates like SendGrid does.
+type Object struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// ParseData decodes JSON test data. Objects are decoded as *Object, numbers as float64.
+func ParseData(src string) (interface{}, error) {
+	if src == "" {
+		return &Object{Values: map[string]interface{}{}}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(src)))
+
+	value, err := decodeValue(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test data: %w", err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid test data: unexpected content after the JSON value")
+	}
+
+	return value, nil
+}
+
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch delim := tok.(type) {
+	case json.Delim:
+		switch delim {
+		case '{':
+			object := &Object{Values: map[string]interface{}{}}
+
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				key, _ := keyToken.(string)
+
+				value, err := decodeValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				if _, exists := object.Values[key]; !exists {
+					object.Keys = append(object.Keys, key)
+				}
+
+				object.Values[key] = value
+			}
+
+			_, err := decoder.Token()
+
+			return object, err
+		case '[':
+			array := []interface{}{}
+
+			for decoder.More() {
+				value, err := decodeValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				array = append(array, value)
+			}
+
+			_, err := decoder.Token()
+
+			return array, err
+		}
+
+		return nil, fmt.Errorf("unexpected %v", delim)
+	default:
+		return tok, nil
+	}
+}
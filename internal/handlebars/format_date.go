@@ -0,0 +1,165 @@
+package handlebars
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// dateTokens are the formatDate tokens documented by SendGrid, longest first.
+var dateTokens = []string{
+	"YYYY", "YY", "MMMM", "MMM", "MM", "M", "DD", "D", "dddd", "ddd",
+	"HH", "H", "hh", "h", "mm", "m", "ss", "s", "A", "a", "ZZ", "Z",
+}
+
+// formatDate implements the SendGrid formatDate helper, timezone is an optional offset such as -0800.
+func formatDate(value interface{}, format, timezone string) (string, error) {
+	t, err := parseDate(value)
+	if err != nil {
+		return "", err
+	}
+
+	if timezone != "" {
+		location, err := parseOffset(timezone)
+		if err != nil {
+			return "", err
+		}
+
+		t = t.In(location)
+	}
+
+	var out strings.Builder
+
+	for i := 0; i < len(format); {
+		// [text] is written as is.
+		if format[i] == '[' {
+			if end := strings.IndexByte(format[i:], ']'); end > 0 {
+				out.WriteString(format[i+1 : i+end])
+				i += end + 1
+
+				continue
+			}
+		}
+
+		token := ""
+
+		for _, candidate := range dateTokens {
+			if strings.HasPrefix(format[i:], candidate) {
+				token = candidate
+
+				break
+			}
+		}
+
+		if token == "" {
+			out.WriteByte(format[i])
+			i++
+
+			continue
+		}
+
+		out.WriteString(formatDateToken(t, token))
+		i += len(token)
+	}
+
+	return out.String(), nil
+}
+
+func formatDateToken(t time.Time, token string) string {
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	switch token {
+	case "YYYY":
+		return fmt.Sprintf("%04d", t.Year())
+	case "YY":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "MMMM":
+		return t.Month().String()
+	case "MMM":
+		return t.Month().String()[:3]
+	case "MM":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "M":
+		return strconv.Itoa(int(t.Month()))
+	case "DD":
+		return fmt.Sprintf("%02d", t.Day())
+	case "D":
+		return strconv.Itoa(t.Day())
+	case "dddd":
+		return t.Weekday().String()
+	case "ddd":
+		return t.Weekday().String()[:3]
+	case "HH":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "H":
+		return strconv.Itoa(t.Hour())
+	case "hh":
+		return fmt.Sprintf("%02d", hour12)
+	case "h":
+		return strconv.Itoa(hour12)
+	case "mm":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "m":
+		return strconv.Itoa(t.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", t.Second())
+	case "s":
+		return strconv.Itoa(t.Second())
+	case "A":
+		return t.Format("PM")
+	case "a":
+		return t.Format("pm")
+	case "ZZ":
+		return t.Format("-0700")
+	case "Z":
+		return t.Format("-07:00")
+	}
+
+	return token
+}
+
+func parseDate(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case float64:
+		// Large timestamps are in milliseconds.
+		if v > 1e11 || v < -1e11 {
+			return time.UnixMilli(int64(v)).UTC(), nil
+		}
+
+		return time.Unix(int64(v), 0).UTC(), nil
+	case string:
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.UTC(), nil
+			}
+		}
+
+		return time.Time{}, fmt.Errorf("can't parse date %q", v)
+	}
+
+	return time.Time{}, fmt.Errorf("can't parse date %v", value)
+}
+
+// parseOffset parses an offset such as -0800 or +05:30.
+func parseOffset(offset string) (*time.Location, error) {
+	for _, layout := range []string{"-0700", "-07:00", "-07"} {
+		if t, err := time.Parse(layout, offset); err == nil {
+			_, seconds := t.Zone()
+
+			return time.FixedZone(offset, seconds), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid timezone offset %q", offset)
+}
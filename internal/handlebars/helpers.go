@@ -0,0 +1,55 @@
+package handlebars
+
+import (
+	"sort"
+	"strings"
+)
+
+// helperKind tells how a helper can be called.
+type helperKind int
+
+const (
+	blockHelper helperKind = iota
+	inlineHelper
+)
+
+type helperSpec struct {
+	kind helperKind
+	// minArgs and maxArgs bound the number of positional arguments, maxArgs < 0 means no limit.
+	minArgs int
+	maxArgs int
+}
+
+// sendgridHelpers are the helpers SendGrid supports in dynamic templates, see
+// https://www.twilio.com/docs/sendgrid/for-developers/sending-email/using-handlebars
+var sendgridHelpers = map[string]helperSpec{
+	"if":          {kind: blockHelper, minArgs: 1, maxArgs: 1},
+	"unless":      {kind: blockHelper, minArgs: 1, maxArgs: 1},
+	"each":        {kind: blockHelper, minArgs: 1, maxArgs: 1},
+	"with":        {kind: blockHelper, minArgs: 1, maxArgs: 1},
+	"equals":      {kind: blockHelper, minArgs: 2, maxArgs: 2},
+	"notEquals":   {kind: blockHelper, minArgs: 2, maxArgs: 2},
+	"greaterThan": {kind: blockHelper, minArgs: 2, maxArgs: 2},
+	"lessThan":    {kind: blockHelper, minArgs: 2, maxArgs: 2},
+	"and":         {kind: blockHelper, minArgs: 2, maxArgs: -1},
+	"or":          {kind: blockHelper, minArgs: 2, maxArgs: -1},
+	"formatDate":  {kind: inlineHelper, minArgs: 2, maxArgs: 3},
+	"insert":      {kind: inlineHelper, minArgs: 1, maxArgs: 2},
+	"length":      {kind: inlineHelper, minArgs: 1, maxArgs: 1},
+}
+
+// Helpers returns the names of the helpers supported by SendGrid, sorted.
+func Helpers() []string {
+	names := make([]string, 0, len(sendgridHelpers))
+	for name := range sendgridHelpers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func helperList() string {
+	return strings.Join(Helpers(), ", ")
+}
@@ -0,0 +1,809 @@
+package handlebars
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a problem found in a template, at a given position.
+type Error struct {
+	Pos
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// Errors are all the problems found in a template, in order of appearance.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (e Errors) sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		if e[i].Line != e[j].Line {
+			return e[i].Line < e[j].Line
+		}
+
+		return e[i].Column < e[j].Column
+	})
+}
+
+type tokenKind int
+
+const (
+	tokText tokenKind = iota
+	tokMustache
+	tokOpen
+	tokInverse
+	tokClose
+	tokElse
+	tokComment
+	tokPartial
+)
+
+type token struct {
+	kind tokenKind
+	// offset of the text or of the opening braces.
+	offset int
+	// value is the text, or the content of the mustache after its sigil.
+	value       string
+	valueOffset int
+	escaped     bool
+	stripLeft   bool
+	stripRight  bool
+	// start and end delimit what remains of a text once standalone lines are removed.
+	start int
+	end   int
+}
+
+func (t *token) standalone() bool {
+	switch t.kind {
+	case tokOpen, tokInverse, tokClose, tokElse, tokComment, tokPartial:
+		return true
+	case tokText, tokMustache:
+	}
+
+	return false
+}
+
+type parser struct {
+	src        string
+	lineStarts []int
+	errs       Errors
+}
+
+// Parse parses a template. Syntax errors are reported all at once, along with a best effort template.
+func Parse(src string) (*Template, error) {
+	p := &parser{src: src, lineStarts: []int{0}}
+
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+
+	tokens := p.scan()
+	p.stripWhitespace(tokens)
+	tmpl := &Template{Nodes: p.build(tokens)}
+
+	if len(p.errs) > 0 {
+		p.errs.sort()
+
+		return tmpl, p.errs
+	}
+
+	return tmpl, nil
+}
+
+func (p *parser) pos(offset int) Pos {
+	line := sort.Search(len(p.lineStarts), func(i int) bool { return p.lineStarts[i] > offset }) - 1
+
+	return Pos{
+		Line:   line + 1,
+		Column: utf8.RuneCountInString(p.src[p.lineStarts[line]:offset]) + 1,
+	}
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) {
+	p.errs = append(p.errs, &Error{Pos: p.pos(offset), Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) scan() []*token {
+	var tokens []*token
+
+	text := func(from, to int) {
+		if from >= to {
+			return
+		}
+
+		if last := len(tokens) - 1; last >= 0 && tokens[last].kind == tokText {
+			tokens[last].value += p.src[from:to]
+			tokens[last].end = len(tokens[last].value)
+
+			return
+		}
+
+		tokens = append(tokens, &token{kind: tokText, offset: from, value: p.src[from:to], start: 0, end: to - from})
+	}
+
+	i := 0
+
+	for i < len(p.src) {
+		o := strings.Index(p.src[i:], "{{")
+		if o < 0 {
+			text(i, len(p.src))
+
+			break
+		}
+
+		o += i
+
+		// \{{ is a literal {{.
+		if o > 0 && p.src[o-1] == '\\' {
+			text(i, o-1)
+			text(o, o+2)
+			i = o + 2
+
+			continue
+		}
+
+		text(i, o)
+
+		tok, next := p.scanTag(o)
+		if tok == nil {
+			text(o, len(p.src))
+
+			break
+		}
+
+		tokens = append(tokens, tok)
+		i = next
+	}
+
+	return tokens
+}
+
+// scanTag scans the mustache starting at offset, and returns the offset following it.
+func (p *parser) scanTag(offset int) (*token, int) {
+	src := p.src
+	start := offset + 2
+	tok := &token{offset: offset, escaped: true}
+
+	if start < len(src) && src[start] == '~' {
+		tok.stripLeft = true
+		start++
+	}
+
+	if strings.HasPrefix(src[start:], "!--") {
+		// The closing --}} is searched after the opening {{!--, which contains -- too.
+		body := start + 3
+		end := strings.Index(src[body:], "--}}")
+		strip := strings.Index(src[body:], "--~}}")
+
+		switch {
+		case strip >= 0 && (end < 0 || strip < end):
+			tok.kind, tok.value, tok.stripRight = tokComment, src[body:body+strip], true
+
+			return tok, body + strip + 5
+		case end >= 0:
+			tok.kind, tok.value = tokComment, src[body:body+end]
+
+			return tok, body + end + 4
+		}
+
+		p.errorf(offset, "unclosed comment, expected --}}")
+
+		return nil, len(src)
+	}
+
+	triple := start < len(src) && src[start] == '{'
+	if triple {
+		start++
+	}
+
+	end, next, ok := p.findClose(start, triple)
+	if !ok {
+		p.errorf(offset, "unclosed mustache, expected %s", map[bool]string{true: "}}}", false: "}}"}[triple])
+
+		return nil, len(src)
+	}
+
+	tok.stripRight = src[end] == '~' || (triple && end+1 < len(src) && src[end+1] == '~')
+	content := src[start:end]
+	trimmed := strings.TrimLeft(content, " \t\r\n")
+	valueOffset := start + len(content) - len(trimmed)
+
+	if triple {
+		tok.kind, tok.escaped, tok.value, tok.valueOffset = tokMustache, false, content, start
+
+		return tok, next
+	}
+
+	sigil := byte(0)
+	if trimmed != "" {
+		sigil = trimmed[0]
+	}
+
+	switch sigil {
+	case '#':
+		tok.kind = tokOpen
+	case '^':
+		tok.kind = tokInverse
+	case '/':
+		tok.kind = tokClose
+	case '>':
+		tok.kind = tokPartial
+	case '!':
+		tok.kind = tokComment
+	case '&':
+		tok.kind, tok.escaped = tokMustache, false
+	default:
+		tok.kind, tok.value, tok.valueOffset = tokMustache, content, start
+
+		if keyword := strings.TrimSpace(content); keyword == "else" || strings.HasPrefix(keyword, "else ") {
+			tok.kind = tokElse
+			tok.value = strings.TrimPrefix(trimmed, "else")
+			tok.valueOffset = valueOffset + len("else")
+		}
+
+		return tok, next
+	}
+
+	tok.value = trimmed[1:]
+	tok.valueOffset = valueOffset + 1
+
+	// {{^}} is another way to write {{else}}.
+	if tok.kind == tokInverse && strings.TrimSpace(tok.value) == "" {
+		tok.kind = tokElse
+	}
+
+	return tok, next
+}
+
+// findClose returns the offset where the content of a mustache ends, and the offset following the mustache.
+func (p *parser) findClose(start int, triple bool) (int, int, bool) {
+	src := p.src
+
+	for j := start; j < len(src); j++ {
+		switch c := src[j]; c {
+		case '"', '\'':
+			if k := strings.IndexByte(src[j+1:], c); k >= 0 {
+				j += k + 1
+			}
+		case '{':
+			if strings.HasPrefix(src[j:], "{{") {
+				return 0, 0, false
+			}
+		case '}', '~':
+			for _, closer := range closers(triple) {
+				if strings.HasPrefix(src[j:], closer) {
+					return j, j + len(closer), true
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+func closers(triple bool) []string {
+	if triple {
+		return []string{"}}}", "}~}}"}
+	}
+
+	return []string{"}}", "~}}"}
+}
+
+// stripWhitespace removes the lines holding only a block tag or a comment, and applies the ~ whitespace controls.
+func (p *parser) stripWhitespace(tokens []*token) {
+	text := func(i int) *token {
+		if i >= 0 && i < len(tokens) && tokens[i].kind == tokText {
+			return tokens[i]
+		}
+
+		return nil
+	}
+
+	for i, tok := range tokens {
+		if !tok.standalone() {
+			continue
+		}
+
+		prev, next := text(i-1), text(i+1)
+
+		startsLine := i == 0 ||
+			(prev != nil && strings.TrimLeft(prev.value[lastLineStart(prev.value):], " \t") == "" &&
+				(strings.Contains(prev.value, "\n") || i == 1))
+		endsLine := i == len(tokens)-1 ||
+			(next != nil && strings.TrimRight(next.value[:firstLineEnd(next.value)], " \t\r") == "" &&
+				(strings.Contains(next.value, "\n") || i == len(tokens)-2))
+
+		if !startsLine || !endsLine {
+			continue
+		}
+
+		if prev != nil && lastLineStart(prev.value) < prev.end {
+			prev.end = lastLineStart(prev.value)
+		}
+
+		if next != nil {
+			if end := firstLineEnd(next.value); end < len(next.value) {
+				next.start = max(next.start, end+1)
+			} else {
+				next.start = len(next.value)
+			}
+		}
+	}
+
+	for i, tok := range tokens {
+		if tok.kind == tokText {
+			continue
+		}
+
+		if prev := text(i - 1); prev != nil && tok.stripLeft {
+			prev.end = prev.start + len(strings.TrimRight(prev.value[prev.start:max(prev.start, prev.end)], " \t\r\n"))
+		}
+
+		if next := text(i + 1); next != nil && tok.stripRight && next.start < next.end {
+			next.start = next.end - len(strings.TrimLeft(next.value[next.start:next.end], " \t\r\n"))
+		}
+	}
+}
+
+func lastLineStart(s string) int {
+	return strings.LastIndexByte(s, '\n') + 1
+}
+
+func firstLineEnd(s string) int {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return i
+	}
+
+	return len(s)
+}
+
+type frame struct {
+	block     *Block
+	target    *Block
+	inInverse bool
+	openedAt  int
+}
+
+func (p *parser) build(tokens []*token) []Node {
+	var (
+		root  []Node
+		stack []*frame
+	)
+
+	add := func(n Node) {
+		if len(stack) == 0 {
+			root = append(root, n)
+
+			return
+		}
+
+		f := stack[len(stack)-1]
+		if f.inInverse {
+			f.target.Inverse = append(f.target.Inverse, n)
+		} else {
+			f.target.Program = append(f.target.Program, n)
+		}
+	}
+
+	for _, tok := range tokens {
+		switch tok.kind {
+		case tokText:
+			if tok.start < tok.end {
+				add(&Text{Pos: p.pos(tok.offset + tok.start), Value: tok.value[tok.start:tok.end]})
+			}
+		case tokComment:
+			add(&Comment{Pos: p.pos(tok.offset), Value: tok.value})
+		case tokPartial:
+			add(&Partial{Pos: p.pos(tok.offset), Name: strings.TrimSpace(tok.value)})
+		case tokMustache:
+			if call, ok := p.parseCall(tok.value, tok.valueOffset, tok.offset); ok {
+				add(&Mustache{Pos: p.pos(tok.offset), Call: call, Escaped: tok.escaped})
+			}
+		case tokOpen, tokInverse:
+			call, ok := p.parseCall(tok.value, tok.valueOffset, tok.offset)
+			if !ok {
+				call = Call{Path: &Path{Pos: p.pos(tok.offset)}}
+			}
+
+			block := &Block{Pos: p.pos(tok.offset), Call: call, Inverted: tok.kind == tokInverse}
+			add(block)
+			stack = append(stack, &frame{block: block, target: block, openedAt: tok.offset})
+		case tokElse:
+			if len(stack) == 0 {
+				p.errorf(tok.offset, "{{else}} outside of a block")
+
+				continue
+			}
+
+			f := stack[len(stack)-1]
+			if f.inInverse {
+				p.errorf(tok.offset, "block %q already has an {{else}}", f.block.Name())
+
+				continue
+			}
+
+			if strings.TrimSpace(tok.value) == "" {
+				f.inInverse = true
+
+				continue
+			}
+
+			call, ok := p.parseCall(tok.value, tok.valueOffset, tok.offset)
+			if !ok {
+				continue
+			}
+
+			chained := &Block{Pos: p.pos(tok.offset), Call: call, Chained: true}
+			f.target.Inverse = []Node{chained}
+			f.target = chained
+		case tokClose:
+			name := strings.TrimSpace(tok.value)
+
+			if len(stack) == 0 {
+				p.errorf(tok.offset, "closing block %q was never opened", name)
+
+				continue
+			}
+
+			f := stack[len(stack)-1]
+			if f.block.Name() != name {
+				open := p.pos(f.openedAt)
+				p.errorf(tok.offset, "block %q opened at line %d, column %d is closed by {{/%s}}",
+					f.block.Name(), open.Line, open.Column, name)
+
+				// Assume the closing tag belongs to a parent block when it matches one.
+				matched := false
+
+				for i := len(stack) - 2; i >= 0; i-- {
+					if stack[i].block.Name() == name {
+						stack = stack[:i]
+						matched = true
+
+						break
+					}
+				}
+
+				if matched {
+					continue
+				}
+			}
+
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for _, f := range stack {
+		p.errorf(f.openedAt, "block %q is never closed, expected {{/%s}}", f.block.Name(), f.block.Name())
+	}
+
+	return root
+}
+
+type exprTokenKind int
+
+const (
+	exprID exprTokenKind = iota
+	exprString
+	exprOpen
+	exprClose
+	exprEquals
+)
+
+type exprToken struct {
+	kind   exprTokenKind
+	value  string
+	offset int
+}
+
+func (p *parser) lexExpr(s string, offset int) ([]exprToken, bool) {
+	var tokens []exprToken
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, exprToken{kind: exprOpen, offset: offset + i})
+			i++
+		case c == ')':
+			tokens = append(tokens, exprToken{kind: exprClose, offset: offset + i})
+			i++
+		case c == '=':
+			tokens = append(tokens, exprToken{kind: exprEquals, offset: offset + i})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				p.errorf(offset+i, "unterminated string")
+
+				return nil, false
+			}
+
+			tokens = append(tokens, exprToken{kind: exprString, value: s[i+1 : i+1+end], offset: offset + i})
+			i += end + 2
+		default:
+			j := i
+
+			for j < len(s) && !strings.ContainsRune(" \t\r\n()=\"'", rune(s[j])) {
+				if s[j] == '[' {
+					end := strings.IndexByte(s[j:], ']')
+					if end < 0 {
+						p.errorf(offset+j, "unterminated [ segment")
+
+						return nil, false
+					}
+
+					j += end
+				}
+
+				j++
+			}
+
+			tokens = append(tokens, exprToken{kind: exprID, value: s[i:j], offset: offset + i})
+			i = j
+		}
+	}
+
+	return tokens, true
+}
+
+// parseCall parses the content of a mustache, tagOffset is used to report empty mustaches.
+func (p *parser) parseCall(s string, offset, tagOffset int) (Call, bool) {
+	tokens, ok := p.lexExpr(s, offset)
+	if !ok {
+		return Call{}, false
+	}
+
+	if len(tokens) == 0 {
+		p.errorf(tagOffset, "empty mustache")
+
+		return Call{}, false
+	}
+
+	e := &exprParser{p: p, tokens: tokens}
+
+	call, ok := e.call(tagOffset)
+	if !ok {
+		return Call{}, false
+	}
+
+	if e.i < len(e.tokens) {
+		p.errorf(e.tokens[e.i].offset, "unexpected %q", e.describe(e.tokens[e.i]))
+
+		return Call{}, false
+	}
+
+	return call, true
+}
+
+type exprParser struct {
+	p      *parser
+	tokens []exprToken
+	i      int
+}
+
+func (e *exprParser) describe(t exprToken) string {
+	switch t.kind {
+	case exprOpen:
+		return "("
+	case exprClose:
+		return ")"
+	case exprEquals:
+		return "="
+	case exprString:
+		return `"` + t.value + `"`
+	case exprID:
+	}
+
+	return t.value
+}
+
+func (e *exprParser) call(offset int) (Call, bool) {
+	if e.i >= len(e.tokens) {
+		e.p.errorf(offset, "expected a helper name or a path")
+
+		return Call{}, false
+	}
+
+	head := e.tokens[e.i]
+	if head.kind != exprID || literalValue(head.value) != nil || isKeywordLiteral(head.value) {
+		e.p.errorf(head.offset, "expected a helper name or a path, got %q", e.describe(head))
+
+		return Call{}, false
+	}
+
+	path, ok := e.p.parsePath(head.value, head.offset)
+	if !ok {
+		return Call{}, false
+	}
+
+	e.i++
+	call := Call{Path: path}
+
+	for e.i < len(e.tokens) && e.tokens[e.i].kind != exprClose {
+		if e.i+1 < len(e.tokens) && e.tokens[e.i].kind == exprID && e.tokens[e.i+1].kind == exprEquals {
+			key := e.tokens[e.i]
+			e.i += 2
+
+			value, ok := e.arg(key.offset)
+			if !ok {
+				return Call{}, false
+			}
+
+			call.Hash = append(call.Hash, &HashPair{Pos: e.p.pos(key.offset), Key: key.value, Value: value})
+
+			continue
+		}
+
+		if len(call.Hash) > 0 {
+			e.p.errorf(e.tokens[e.i].offset, "positional argument after key=value arguments")
+
+			return Call{}, false
+		}
+
+		arg, ok := e.arg(e.tokens[e.i].offset)
+		if !ok {
+			return Call{}, false
+		}
+
+		call.Params = append(call.Params, arg)
+	}
+
+	return call, true
+}
+
+func (e *exprParser) arg(offset int) (Expr, bool) {
+	if e.i >= len(e.tokens) {
+		e.p.errorf(offset, "missing value")
+
+		return nil, false
+	}
+
+	t := e.tokens[e.i]
+	e.i++
+
+	switch t.kind {
+	case exprString:
+		return &Literal{Pos: e.p.pos(t.offset), Value: t.value}, true
+	case exprOpen:
+		call, ok := e.call(t.offset)
+		if !ok {
+			return nil, false
+		}
+
+		if e.i >= len(e.tokens) || e.tokens[e.i].kind != exprClose {
+			e.p.errorf(t.offset, "unclosed (, expected )")
+
+			return nil, false
+		}
+
+		e.i++
+
+		return &SubExpr{Pos: e.p.pos(t.offset), Call: call}, true
+	case exprID:
+		if value := literalValue(t.value); value != nil || isKeywordLiteral(t.value) {
+			return &Literal{Pos: e.p.pos(t.offset), Value: value}, true
+		}
+
+		return e.p.parsePath(t.value, t.offset)
+	case exprClose, exprEquals:
+	}
+
+	e.p.errorf(t.offset, "unexpected %q", e.describe(t))
+
+	return nil, false
+}
+
+// literalValue returns the value of number and boolean literals.
+func literalValue(s string) interface{} {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if n, err := strconv.ParseFloat(s, 64); err == nil && s != "" && (s[0] == '-' || (s[0] >= '0' && s[0] <= '9')) {
+		return n
+	}
+
+	return nil
+}
+
+func isKeywordLiteral(s string) bool {
+	return s == "null" || s == "undefined"
+}
+
+func (p *parser) parsePath(original string, offset int) (*Path, bool) {
+	path := &Path{Pos: p.pos(offset), Original: original}
+	s := original
+
+	if strings.HasPrefix(s, "@") {
+		path.Data = true
+		s = s[1:]
+	}
+
+	for strings.HasPrefix(s, "../") || s == ".." {
+		path.Depth++
+		s = strings.TrimPrefix(strings.TrimPrefix(s, ".."), "/")
+	}
+
+	if s == "." {
+		s = ""
+	}
+
+	s = strings.TrimPrefix(s, "./")
+
+	for i := 0; i < len(s); {
+		var segment string
+
+		if s[i] == '[' {
+			end := strings.IndexByte(s[i:], ']')
+			segment = s[i+1 : i+end]
+			i += end + 1
+		} else {
+			end := strings.IndexAny(s[i:], "./")
+			if end < 0 {
+				end = len(s) - i
+			}
+
+			segment = s[i : i+end]
+			i += end
+
+			if segment == "" {
+				p.errorf(offset, "invalid path %q", original)
+
+				return nil, false
+			}
+
+			if segment == "this" && len(path.Parts) == 0 && i == len("this") {
+				segment = ""
+			} else if segment == "this" || segment == ".." {
+				p.errorf(offset, "invalid path %q, %s must be at the beginning", original, segment)
+
+				return nil, false
+			}
+		}
+
+		if segment != "" {
+			path.Parts = append(path.Parts, segment)
+		}
+
+		if i < len(s) {
+			if s[i] != '.' && s[i] != '/' {
+				p.errorf(offset, "invalid path %q", original)
+
+				return nil, false
+			}
+
+			i++
+
+			if i == len(s) {
+				p.errorf(offset, "invalid path %q", original)
+
+				return nil, false
+			}
+		}
+	}
+
+	return path, true
+}
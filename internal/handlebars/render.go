@@ -0,0 +1,473 @@
+package handlebars
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Render parses a template and renders it against data, as returned by ParseData.
+func Render(src string, data interface{}) (string, error) {
+	tmpl, err := Parse(src)
+	if err != nil {
+		return "", err
+	}
+
+	return tmpl.Render(data)
+}
+
+// Render renders the template against data, as returned by ParseData.
+// Like SendGrid, {{expression}} is HTML escaped while {{{expression}}} isn't.
+func (t *Template) Render(data interface{}) (string, error) {
+	r := &renderer{}
+
+	var out strings.Builder
+	if err := r.nodes(&out, t.Nodes, []*scope{{value: data, data: map[string]interface{}{"root": data}}}); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// scope is a context pushed by a block, with the @ variables it defines.
+type scope struct {
+	value interface{}
+	data  map[string]interface{}
+}
+
+type renderer struct{}
+
+func renderError(pos Pos, format string, args ...interface{}) error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (r *renderer) nodes(out *strings.Builder, nodes []Node, scopes []*scope) error {
+	for _, node := range nodes {
+		var err error
+
+		switch n := node.(type) {
+		case *Text:
+			out.WriteString(n.Value)
+		case *Mustache:
+			err = r.mustache(out, n, scopes)
+		case *Block:
+			err = r.block(out, n, scopes)
+		case *Partial:
+			err = renderError(n.Pos, "partials are not supported by SendGrid")
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *renderer) mustache(out *strings.Builder, m *Mustache, scopes []*scope) error {
+	value, err := r.call(&m.Call, m.Pos, scopes)
+	if err != nil {
+		return err
+	}
+
+	s := toString(value)
+	if m.Escaped {
+		s = escape(s)
+	}
+
+	out.WriteString(s)
+
+	return nil
+}
+
+// call evaluates an inline helper call, or looks up a path.
+func (r *renderer) call(call *Call, pos Pos, scopes []*scope) (interface{}, error) {
+	spec, known := sendgridHelpers[call.Name()]
+
+	if !known {
+		if call.IsHelperCall() {
+			return nil, renderError(pos, "unknown helper %q", call.Name())
+		}
+
+		return r.lookup(call.Path, scopes), nil
+	}
+
+	if spec.kind == blockHelper {
+		return nil, renderError(pos, "%q is a block helper", call.Name())
+	}
+
+	params, err := r.params(call, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(params) < spec.minArgs {
+		return nil, renderError(pos, "helper %q expects at least %d arguments", call.Name(), spec.minArgs)
+	}
+
+	switch call.Name() {
+	case "length":
+		return float64(length(params[0])), nil
+	case "insert":
+		return r.insert(call, params, scopes)
+	case "formatDate":
+		timezone := ""
+		if len(params) > 2 {
+			timezone = toString(params[2])
+		}
+
+		formatted, err := formatDate(params[0], toString(params[1]), timezone)
+		if err != nil {
+			return nil, renderError(pos, "formatDate: %v", err)
+		}
+
+		return formatted, nil
+	}
+
+	return nil, renderError(pos, "unsupported helper %q", call.Name())
+}
+
+// insert returns its first argument, or the default given as "default=value" when it is empty.
+func (r *renderer) insert(call *Call, params []interface{}, scopes []*scope) (interface{}, error) {
+	if truthy(params[0]) || isZero(params[0]) {
+		return params[0], nil
+	}
+
+	if len(params) > 1 {
+		return strings.TrimPrefix(toString(params[1]), "default="), nil
+	}
+
+	for _, pair := range call.Hash {
+		if pair.Key == "default" {
+			return r.expr(pair.Value, scopes)
+		}
+	}
+
+	return "", nil
+}
+
+func (r *renderer) params(call *Call, scopes []*scope) ([]interface{}, error) {
+	params := make([]interface{}, len(call.Params))
+
+	for i, param := range call.Params {
+		value, err := r.expr(param, scopes)
+		if err != nil {
+			return nil, err
+		}
+
+		params[i] = value
+	}
+
+	return params, nil
+}
+
+func (r *renderer) expr(expr Expr, scopes []*scope) (interface{}, error) {
+	switch e := expr.(type) {
+	case *Literal:
+		return e.Value, nil
+	case *Path:
+		return r.lookup(e, scopes), nil
+	case *SubExpr:
+		return r.call(&e.Call, e.Pos, scopes)
+	}
+
+	return nil, nil
+}
+
+func (r *renderer) lookup(path *Path, scopes []*scope) interface{} {
+	if path.Data {
+		if len(path.Parts) == 0 {
+			return nil
+		}
+
+		value := scopes[len(scopes)-1].data[path.Parts[0]]
+
+		return lookupParts(value, path.Parts[1:])
+	}
+
+	index := len(scopes) - 1 - path.Depth
+	if index < 0 {
+		return nil
+	}
+
+	return lookupParts(scopes[index].value, path.Parts)
+}
+
+func lookupParts(value interface{}, parts []string) interface{} {
+	for _, part := range parts {
+		switch v := value.(type) {
+		case *Object:
+			value = v.Values[part]
+		case []interface{}:
+			if part == "length" {
+				value = float64(len(v))
+
+				continue
+			}
+
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+
+			value = v[i]
+		case string:
+			if part != "length" {
+				return nil
+			}
+
+			value = float64(len([]rune(v)))
+		default:
+			return nil
+		}
+	}
+
+	return value
+}
+
+func (r *renderer) block(out *strings.Builder, b *Block, scopes []*scope) error {
+	if b.Inverted {
+		if truthy(r.lookup(b.Path, scopes)) {
+			return nil
+		}
+
+		return r.nodes(out, b.Program, scopes)
+	}
+
+	spec, known := sendgridHelpers[b.Name()]
+	if known && spec.kind != blockHelper {
+		return renderError(b.Pos, "%q isn't a block helper", b.Name())
+	}
+
+	if !known {
+		if b.IsHelperCall() {
+			return renderError(b.Pos, "unknown block helper %q", b.Name())
+		}
+
+		return r.section(out, b, r.lookup(b.Path, scopes), scopes)
+	}
+
+	params, err := r.params(&b.Call, scopes)
+	if err != nil {
+		return err
+	}
+
+	if len(params) < spec.minArgs {
+		return renderError(b.Pos, "helper %q expects at least %d arguments", b.Name(), spec.minArgs)
+	}
+
+	var condition bool
+
+	switch b.Name() {
+	case "each":
+		return r.each(out, b, params[0], scopes)
+	case "with":
+		if !truthy(params[0]) {
+			return r.nodes(out, b.Inverse, scopes)
+		}
+
+		return r.nodes(out, b.Program, push(scopes, params[0], nil))
+	case "if":
+		condition = truthy(params[0])
+	case "unless":
+		condition = !truthy(params[0])
+	case "equals":
+		condition = equals(params[0], params[1])
+	case "notEquals":
+		condition = !equals(params[0], params[1])
+	case "greaterThan", "lessThan":
+		a, okA := toNumber(params[0])
+		c, okC := toNumber(params[1])
+		condition = okA && okC && ((b.Name() == "greaterThan" && a > c) || (b.Name() == "lessThan" && a < c))
+	case "and":
+		condition = true
+		for _, param := range params {
+			condition = condition && truthy(param)
+		}
+	case "or":
+		for _, param := range params {
+			condition = condition || truthy(param)
+		}
+	}
+
+	if condition {
+		return r.nodes(out, b.Program, scopes)
+	}
+
+	return r.nodes(out, b.Inverse, scopes)
+}
+
+// section renders {{#path}}...{{/path}} like Handlebars: iterate on arrays, push objects, test other values.
+func (r *renderer) section(out *strings.Builder, b *Block, value interface{}, scopes []*scope) error {
+	switch v := value.(type) {
+	case []interface{}:
+		return r.each(out, b, v, scopes)
+	case *Object:
+		return r.nodes(out, b.Program, push(scopes, v, nil))
+	}
+
+	if truthy(value) {
+		return r.nodes(out, b.Program, scopes)
+	}
+
+	return r.nodes(out, b.Inverse, scopes)
+}
+
+func (r *renderer) each(out *strings.Builder, b *Block, value interface{}, scopes []*scope) error {
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			return r.nodes(out, b.Inverse, scopes)
+		}
+
+		for i, item := range v {
+			data := map[string]interface{}{
+				"index": float64(i),
+				"key":   float64(i),
+				"first": i == 0,
+				"last":  i == len(v)-1,
+			}
+
+			if err := r.nodes(out, b.Program, push(scopes, item, data)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *Object:
+		if len(v.Keys) == 0 {
+			return r.nodes(out, b.Inverse, scopes)
+		}
+
+		for i, key := range v.Keys {
+			data := map[string]interface{}{
+				"index": float64(i),
+				"key":   key,
+				"first": i == 0,
+				"last":  i == len(v.Keys)-1,
+			}
+
+			if err := r.nodes(out, b.Program, push(scopes, v.Values[key], data)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return r.nodes(out, b.Inverse, scopes)
+}
+
+func push(scopes []*scope, value interface{}, data map[string]interface{}) []*scope {
+	merged := map[string]interface{}{}
+	for k, v := range scopes[len(scopes)-1].data {
+		merged[k] = v
+	}
+
+	for k, v := range data {
+		merged[k] = v
+	}
+
+	next := make([]*scope, len(scopes), len(scopes)+1)
+	copy(next, scopes)
+
+	return append(next, &scope{value: value, data: merged})
+}
+
+// truthy follows Handlebars: false, null, "", 0 and [] are falsy.
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case []interface{}:
+		return len(v) > 0
+	}
+
+	return true
+}
+
+func isZero(value interface{}) bool {
+	v, ok := value.(float64)
+
+	return ok && v == 0
+}
+
+func length(value interface{}) int {
+	switch v := value.(type) {
+	case []interface{}:
+		return len(v)
+	case *Object:
+		return len(v.Keys)
+	case string:
+		return len([]rune(v))
+	}
+
+	return 0
+}
+
+// equals compares the values as they would be printed, so that 1 equals "1".
+func equals(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return toString(a) == toString(b)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+
+		return n, err == nil
+	}
+
+	return 0, false
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = toString(item)
+		}
+
+		return strings.Join(items, ",")
+	case *Object:
+		return "[object Object]"
+	}
+
+	return fmt.Sprint(value)
+}
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#x27;",
+	"`", "&#x60;",
+	"=", "&#x3D;",
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
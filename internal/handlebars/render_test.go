@@ -0,0 +1,106 @@
+package handlebars
+
+import (
+	"testing"
+)
+
+func TestRender(t *testing.T) { //nolint:funlen
+	data := `{
+		"first_name": "Ada",
+		"company": "Tom & Jerry",
+		"html": "<b>bold</b>",
+		"vip": true,
+		"points": 0,
+		"since": "2020-01-01T23:00:00.000Z",
+		"items": [{"name": "Book", "price": 12.5}, {"name": "Pen", "price": 2}],
+		"empty": [],
+		"address": {"city": "Paris", "zip": "75001"}
+	}`
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "variables are escaped", src: "Hi {{first_name}} from {{company}}", want: "Hi Ada from Tom &amp; Jerry"},
+		{name: "raw variables", src: "{{{html}}} {{&html}}", want: "<b>bold</b> <b>bold</b>"},
+		{name: "missing variables", src: "[{{missing}}][{{address.missing.deep}}]", want: "[][]"},
+		{name: "if else", src: "{{#if vip}}VIP{{else}}regular{{/if}}", want: "VIP"},
+		{name: "zero is falsy", src: "{{#if points}}points{{else}}no points{{/if}}", want: "no points"},
+		{name: "else if", src: "{{#if missing}}a{{else if vip}}b{{else}}c{{/if}}", want: "b"},
+		{name: "unless", src: "{{#unless empty}}nothing{{/unless}}", want: "nothing"},
+		{
+			name: "each with data variables",
+			src:  "{{#each items}}{{@index}}:{{name}}={{price}}{{#unless @last}}, {{/unless}}{{/each}}",
+			want: "0:Book=12.5, 1:Pen=2",
+		},
+		{name: "each on empty", src: "{{#each empty}}x{{else}}none{{/each}}", want: "none"},
+		{name: "each on object", src: "{{#each address}}{{@key}}={{this}};{{/each}}", want: "city=Paris;zip=75001;"},
+		{name: "parent context", src: "{{#each items}}{{../first_name}}{{/each}}", want: "AdaAda"},
+		{name: "root", src: "{{#with address}}{{city}} {{@root.first_name}}{{/with}}", want: "Paris Ada"},
+		{name: "equals", src: `{{#equals address.zip 75001}}yes{{else}}no{{/equals}}`, want: "yes"},
+		{name: "notEquals", src: `{{#notEquals first_name "Ada"}}yes{{else}}no{{/notEquals}}`, want: "no"},
+		{name: "greaterThan with length", src: "{{#greaterThan (length items) 1}}many{{/greaterThan}}", want: "many"},
+		{name: "lessThan", src: "{{#lessThan points 1}}low{{/lessThan}}", want: "low"},
+		{name: "and or", src: "{{#and vip first_name}}a{{/and}}{{#or missing empty}}b{{else}}c{{/or}}", want: "ac"},
+		{name: "insert", src: `{{insert first_name "default=Customer"}} {{insert last_name "default=Customer"}}`, want: "Ada Customer"},
+		{name: "formatDate", src: `{{formatDate since "dddd, MMMM D, YYYY [at] h:mm A"}}`, want: "Wednesday, January 1, 2020 at 11:00 PM"},
+		{name: "formatDate with offset", src: `{{formatDate since "YYYY-MM-DD HH:mm ZZ" "-0800"}}`, want: "2020-01-01 15:00 -0800"},
+		{name: "section", src: "{{#address}}{{city}}{{/address}}{{^missing}}!{{/missing}}", want: "Paris!"},
+		{
+			name: "standalone lines",
+			src:  "<ul>\n  {{#each items}}\n  <li>{{name}}</li>\n  {{/each}}\n</ul>",
+			want: "<ul>\n  <li>Book</li>\n  <li>Pen</li>\n</ul>",
+		},
+	}
+
+	parsed, err := ParseData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.src, parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	for _, src := range []string{
+		"{{#if a}}",
+		"{{uppercase name}}",
+		`{{formatDate "not a date" "YYYY"}}`,
+	} {
+		if _, err := Render(src, nil); err == nil {
+			t.Errorf("expected an error rendering %q", src)
+		}
+	}
+}
+
+func TestParseData(t *testing.T) {
+	value, err := ParseData(`{"b": 1, "a": [true, null, "x"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object, ok := value.(*Object)
+	if !ok || len(object.Keys) != 2 || object.Keys[0] != "b" || object.Keys[1] != "a" {
+		t.Fatalf("expected the keys in order, got %#v", value)
+	}
+
+	if _, err := ParseData(`{"a": 1} {}`); err == nil {
+		t.Error("expected an error for trailing content")
+	}
+
+	if _, err := ParseData(`{"a": }`); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,124 @@
+package handlebars
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate parses a template and checks that it only calls the helpers supported by SendGrid,
+// with the expected number of arguments. It returns nil when the template is valid, Errors otherwise.
+func Validate(src string) error {
+	tmpl, err := Parse(src)
+
+	var errs Errors
+	if err != nil && !errors.As(err, &errs) {
+		return err
+	}
+
+	v := &validator{errs: errs}
+	v.nodes(tmpl.Nodes)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	v.errs.sort()
+
+	return v.errs
+}
+
+type validator struct {
+	errs Errors
+}
+
+func (v *validator) errorf(pos Pos, format string, args ...interface{}) {
+	v.errs = append(v.errs, &Error{Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) nodes(nodes []Node) {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *Mustache:
+			v.mustache(n)
+		case *Block:
+			v.block(n)
+		case *Partial:
+			v.errorf(n.Pos, "partials are not supported by SendGrid, found {{> %s}}", n.Name)
+		}
+	}
+}
+
+func (v *validator) mustache(m *Mustache) {
+	spec, known := sendgridHelpers[m.Name()]
+
+	switch {
+	case known && spec.kind == blockHelper:
+		v.errorf(m.Pos, "%q is a block helper, use {{#%s}}...{{/%s}}", m.Name(), m.Name(), m.Name())
+	case known:
+		v.arity(m.Pos, m.Name(), spec, &m.Call)
+	case m.IsHelperCall():
+		v.errorf(m.Pos, "unknown helper %q, SendGrid supports: %s", m.Name(), helperList())
+	}
+
+	v.args(&m.Call)
+}
+
+func (v *validator) block(b *Block) {
+	spec, known := sendgridHelpers[b.Name()]
+
+	switch {
+	case b.Inverted && b.IsHelperCall():
+		v.errorf(b.Pos, "inverted sections can't take arguments, use {{#unless}} instead")
+	case known && spec.kind == inlineHelper:
+		v.errorf(b.Pos, "%q isn't a block helper, use {{%s}}", b.Name(), b.Name())
+	case known:
+		v.arity(b.Pos, b.Name(), spec, &b.Call)
+	case b.IsHelperCall():
+		v.errorf(b.Pos, "unknown block helper %q, SendGrid supports: %s", b.Name(), helperList())
+	}
+
+	v.args(&b.Call)
+	v.nodes(b.Program)
+	v.nodes(b.Inverse)
+}
+
+func (v *validator) arity(pos Pos, name string, spec helperSpec, call *Call) {
+	count := len(call.Params)
+
+	switch {
+	case spec.maxArgs < 0 && count < spec.minArgs:
+		v.errorf(pos, "helper %q expects at least %d arguments, got %d", name, spec.minArgs, count)
+	case spec.maxArgs >= 0 && spec.minArgs == spec.maxArgs && count != spec.minArgs:
+		v.errorf(pos, "helper %q expects %d argument(s), got %d", name, spec.minArgs, count)
+	case spec.maxArgs >= 0 && (count < spec.minArgs || count > spec.maxArgs):
+		v.errorf(pos, "helper %q expects %d to %d arguments, got %d", name, spec.minArgs, spec.maxArgs, count)
+	}
+}
+
+// args checks the sub-expressions used as arguments.
+func (v *validator) args(call *Call) {
+	exprs := append([]Expr{}, call.Params...)
+	for _, pair := range call.Hash {
+		exprs = append(exprs, pair.Value)
+	}
+
+	for _, expr := range exprs {
+		sub, ok := expr.(*SubExpr)
+		if !ok {
+			continue
+		}
+
+		spec, known := sendgridHelpers[sub.Name()]
+
+		switch {
+		case !known:
+			v.errorf(sub.Pos, "unknown helper %q, SendGrid supports: %s", sub.Name(), helperList())
+		case spec.kind == blockHelper:
+			v.errorf(sub.Pos, "%q is a block helper and can't be used as a sub-expression", sub.Name())
+		default:
+			v.arity(sub.Pos, sub.Name(), spec, &sub.Call)
+		}
+
+		v.args(&sub.Call)
+	}
+}
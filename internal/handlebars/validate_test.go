@@ -0,0 +1,216 @@
+package handlebars
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) { //nolint:funlen
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "plain html",
+			src:  "<p>Hello</p>",
+		},
+		{
+			name: "supported helpers",
+			src: `<p>Hello {{insert first_name "default=Customer"}}</p>
+{{#if user.isVip}}
+  <p>VIP since {{formatDate user.since "MMMM DD, YYYY"}}</p>
+{{else if user.isNew}}
+  <p>Welcome!</p>
+{{else}}
+  <p>Hi again</p>
+{{/if}}
+{{#each items}}
+  {{#greaterThan (length ../items) 1}}{{@index}}: {{this.name}}{{/greaterThan}}
+{{/each}}
+{{#and a b c}}all{{/and}}
+{{{rawHtml}}}
+{{! a comment }}
+{{!-- a {{longer}} comment --}}
+\{{ not a mustache }}`,
+		},
+		{
+			name: "unclosed block",
+			src:  "<p>\n  {{#if name}}Hello</p>",
+			want: []string{`line 2, column 3: block "if" is never closed, expected {{/if}}`},
+		},
+		{
+			name: "mismatched block",
+			src:  "{{#each items}}\n{{#if name}}{{name}}{{/each}}",
+			want: []string{`line 2, column 21: block "if" opened at line 2, column 1 is closed by {{/each}}`},
+		},
+		{
+			name: "closing without opening",
+			src:  "{{name}}{{/if}}",
+			want: []string{`line 1, column 9: closing block "if" was never opened`},
+		},
+		{
+			name: "else outside of block",
+			src:  "a {{else}} b",
+			want: []string{`line 1, column 3: {{else}} outside of a block`},
+		},
+		{
+			name: "unknown helper",
+			src:  "Hi {{uppercase name}}\n{{#ifCond a b}}x{{/ifCond}}",
+			want: []string{
+				`line 1, column 4: unknown helper "uppercase"`,
+				`line 2, column 1: unknown block helper "ifCond"`,
+			},
+		},
+		{
+			name: "unknown helper in sub-expression",
+			src:  "{{#if (isEmpty items)}}none{{/if}}",
+			want: []string{`line 1, column 7: unknown helper "isEmpty"`},
+		},
+		{
+			name: "wrong number of arguments",
+			src:  "{{#if}}x{{/if}}{{#equals a}}y{{/equals}}",
+			want: []string{
+				`line 1, column 1: helper "if" expects 1 argument(s), got 0`,
+				`line 1, column 16: helper "equals" expects 2 argument(s), got 1`,
+			},
+		},
+		{
+			name: "block helper used inline",
+			src:  "{{each items}}",
+			want: []string{`line 1, column 1: "each" is a block helper`},
+		},
+		{
+			name: "unclosed mustache",
+			src:  "<p>\n\t{{name</p>",
+			want: []string{`line 2, column 2: unclosed mustache, expected }}`},
+		},
+		{
+			name: "empty long comments",
+			src:  "{{!----}}{{!----~}}",
+		},
+		{
+			name: "long comment closed by its opening",
+			src:  "{{!--}}",
+			want: []string{`line 1, column 1: unclosed comment, expected --}}`},
+		},
+		{
+			name: "long comment closed by a single dash",
+			src:  "{{!---}}",
+			want: []string{`line 1, column 1: unclosed comment, expected --}}`},
+		},
+		{
+			name: "long comment closed by its opening with whitespace control",
+			src:  "{{!--~}}",
+			want: []string{`line 1, column 1: unclosed comment, expected --}}`},
+		},
+		{
+			name: "unterminated string",
+			src:  `{{insert name "default=x}}`,
+			want: []string{`line 1, column 15: unterminated string`},
+		},
+		{
+			name: "partials",
+			src:  "{{> footer}}",
+			want: []string{`line 1, column 1: partials are not supported by SendGrid`},
+		},
+		{
+			name: "columns count runes",
+			src:  "Héllo {{#if a}}",
+			want: []string{`line 1, column 7: block "if" is never closed`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.src)
+
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected Errors, got %v", err)
+			}
+
+			if len(errs) != len(tt.want) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tt.want), len(errs), err)
+			}
+
+			for i, want := range tt.want {
+				if !strings.HasPrefix(errs[i].Error(), want) {
+					t.Errorf("error %d: expected %q, got %q", i, want, errs[i].Error())
+				}
+			}
+		})
+	}
+}
+
+func TestParseStandaloneLines(t *testing.T) {
+	tmpl, err := Parse("<ul>\n  {{#each items}}\n  <li>{{name}}</li>\n  {{/each}}\n</ul>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tmpl.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %d", len(tmpl.Nodes))
+	}
+
+	if text := tmpl.Nodes[0].(*Text).Value; text != "<ul>\n" {
+		t.Errorf("expected the line of the opening tag to be removed, got %q", text)
+	}
+
+	block := tmpl.Nodes[1].(*Block)
+	if text := block.Program[0].(*Text).Value; text != "  <li>" {
+		t.Errorf("unexpected block content %q", text)
+	}
+
+	if text := tmpl.Nodes[2].(*Text).Value; text != "</ul>" {
+		t.Errorf("expected the line of the closing tag to be removed, got %q", text)
+	}
+}
+
+func TestParseWhitespaceControl(t *testing.T) {
+	tmpl, err := Parse("a  {{~name~}}  b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tmpl.Nodes[0].(*Text).Value + "|" + tmpl.Nodes[2].(*Text).Value; got != "a|b" {
+		t.Errorf("expected the whitespace around the mustache to be removed, got %q", got)
+	}
+}
+
+func TestParsePaths(t *testing.T) {
+	tmpl, err := Parse(`{{../user.[first name]}}{{@index}}{{this}}{{./name}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make([]*Path, 0, len(tmpl.Nodes))
+	for _, node := range tmpl.Nodes {
+		paths = append(paths, node.(*Mustache).Path)
+	}
+
+	if p := paths[0]; p.Depth != 1 || strings.Join(p.Parts, "|") != "user|first name" {
+		t.Errorf("unexpected parent path %+v", p)
+	}
+
+	if p := paths[1]; !p.Data || strings.Join(p.Parts, "|") != "index" {
+		t.Errorf("unexpected data path %+v", p)
+	}
+
+	if p := paths[2]; len(p.Parts) != 0 {
+		t.Errorf("unexpected this path %+v", p)
+	}
+
+	if p := paths[3]; strings.Join(p.Parts, "|") != "name" {
+		t.Errorf("unexpected relative path %+v", p)
+	}
+}
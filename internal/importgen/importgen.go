@@ -0,0 +1,250 @@
+// Package importgen generates the Terraform configuration adopting existing templates:
+// Terraform 1.5 import blocks and the matching sendgrid_template and sendgrid_template_version resources.
+package importgen
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/plaintext"
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Options changes the generated configuration.
+type Options struct {
+	// ContentDir, when set, is the directory where the contents of the versions are written,
+	// referenced from the configuration with html_content_file and plain_content_file.
+	// Otherwise the contents are inlined.
+	ContentDir string
+}
+
+// ReadTemplates reads the given templates and all their versions, with their content.
+// Without IDs, all the legacy and dynamic templates are read.
+func ReadTemplates(ctx context.Context, c *sendgrid.Client, ids []string) ([]sendgrid.Template, error) {
+	// The calls aren't made for a resource, the default timeout of the retries applies.
+	d := &schema.ResourceData{}
+
+	if len(ids) == 0 {
+		templatesStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.ReadTemplates(ctx, "legacy,dynamic")
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, template := range templatesStruct.([]sendgrid.Template) {
+			ids = append(ids, template.ID)
+		}
+	}
+
+	templates := make([]sendgrid.Template, 0, len(ids))
+
+	for _, id := range ids {
+		templateStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.ReadTemplate(ctx, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		template := templateStruct.(*sendgrid.Template)
+
+		for i, version := range template.Versions {
+			versionStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+				return c.ReadTemplateVersion(ctx, template.ID, version.ID)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			template.Versions[i] = *versionStruct.(*sendgrid.TemplateVersion)
+		}
+
+		templates = append(templates, *template)
+	}
+
+	return templates, nil
+}
+
+// Generate writes the import blocks and the resources of the given templates and their versions.
+func Generate(w io.Writer, templates []sendgrid.Template, opts Options) error {
+	g := &generator{w: w, opts: opts, names: map[string]bool{}}
+
+	for _, template := range templates {
+		if err := g.template(template); err != nil {
+			return err
+		}
+	}
+
+	return g.err
+}
+
+type generator struct {
+	w     io.Writer
+	opts  Options
+	names map[string]bool
+	err   error
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	if g.err != nil {
+		return
+	}
+
+	_, g.err = fmt.Fprintf(g.w, format, args...)
+}
+
+func (g *generator) template(template sendgrid.Template) error {
+	name := g.uniqueName(template.Name)
+
+	g.printf("import {\n  to = sendgrid_template.%s\n  id = %s\n}\n\n", name, quote(template.ID))
+	g.printf("resource \"sendgrid_template\" %s {\n", quote(name))
+	g.printf("  name       = %s\n", quote(template.Name))
+	g.printf("  generation = %s\n", quote(template.Generation))
+	g.printf("}\n\n")
+
+	versionNames := map[string]bool{}
+
+	for _, version := range template.Versions {
+		versionName := uniqueName(versionNames, version.Name)
+
+		if err := g.version(name, versionName, template.ID, version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) version(templateName, versionName, templateID string, version sendgrid.TemplateVersion) error {
+	name := g.uniqueName(templateName + "_" + versionName)
+
+	g.printf("import {\n  to = sendgrid_template_version.%s\n  id = %s\n}\n\n", name, quote(templateID+"/"+version.ID))
+	g.printf("resource \"sendgrid_template_version\" %s {\n", quote(name))
+	g.printf("  template_id = sendgrid_template.%s.id\n", templateName)
+	g.printf("  name        = %s\n", quote(version.Name))
+	g.printf("  subject     = %s\n", quote(version.Subject))
+
+	if version.Editor != "" {
+		g.printf("  editor      = %s\n", quote(version.Editor))
+	}
+
+	if version.Active == 1 {
+		g.printf("  active      = 1\n")
+	}
+
+	// The plain content is only written when it differs from the one generated by the provider.
+	generatePlainContent := version.PlainContent == plaintext.FromHTML(version.HTMLContent)
+	if !generatePlainContent {
+		g.printf("\n  generate_plain_content = false\n")
+	}
+
+	if err := g.content("html_content", path.Join(templateName, versionName+".html"), version.HTMLContent); err != nil {
+		return err
+	}
+
+	if !generatePlainContent {
+		if err := g.content("plain_content", path.Join(templateName, versionName+".txt"), version.PlainContent); err != nil {
+			return err
+		}
+	}
+
+	if version.TestData != "" {
+		g.printf("\n  test_data = %s\n", quote(version.TestData))
+	}
+
+	g.printf("}\n\n")
+
+	return nil
+}
+
+// content writes the argument of a content, in a file of the content directory when there is one.
+func (g *generator) content(key, file, content string) error {
+	if g.opts.ContentDir == "" {
+		g.printf("\n  %s = %s\n", key, quote(content))
+
+		return nil
+	}
+
+	dest := filepath.Join(g.opts.ContentDir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("failed creating the content directory: %w", err)
+	}
+
+	if err := os.WriteFile(dest, []byte(content), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed writing %s: %w", dest, err)
+	}
+
+	file = quote(filepath.ToSlash(dest))
+	if !filepath.IsAbs(dest) {
+		file = `"${path.module}/` + file[1:]
+	}
+
+	g.printf("\n  %s_file = %s\n", key, file)
+
+	return nil
+}
+
+func (g *generator) uniqueName(s string) string {
+	return uniqueName(g.names, s)
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueName returns a Terraform name derived from s and not in names yet, then adds it to names.
+func uniqueName(names map[string]bool, s string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "t_" + name
+	}
+
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+
+	names[unique] = true
+
+	return unique
+}
+
+// quote returns s as an HCL quoted string, where template sequences are escaped.
+func quote(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '$', '%':
+			// ${ and %{ start template sequences, they're escaped as $${ and %%{.
+			if i+1 < len(s) && s[i+1] == '{' {
+				b.WriteByte(c)
+			}
+
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package importgen_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/importgen"
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+var templates = []sendgrid.Template{
+	{
+		ID:         "d-1",
+		Name:       "Welcome email",
+		Generation: "dynamic",
+		Versions: []sendgrid.TemplateVersion{
+			{
+				ID:           "v-1",
+				Name:         "v1",
+				Subject:      "Hello {{first_name}}",
+				HTMLContent:  "<p>Hello \"${name}\" %{if}\\</p>",
+				PlainContent: "Hello \"${name}\" %{if}\\",
+				Editor:       "code",
+				Active:       1,
+				TestData:     `{"first_name": "Ada"}`,
+			},
+			{
+				ID:           "v-2",
+				Name:         "v1",
+				Subject:      "Hi",
+				HTMLContent:  "<p>Hi</p>",
+				PlainContent: "Hi there\n",
+			},
+		},
+	},
+	{ID: "d-2", Name: "Welcome-email", Generation: "legacy"},
+}
+
+func TestGenerate(t *testing.T) {
+	var b strings.Builder
+
+	if err := importgen.Generate(&b, templates, importgen.Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(b.String()), "imports.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("invalid configuration: %v\n%s", diags, b.String())
+	}
+
+	blocks := file.Body.(*hclsyntax.Body).Blocks
+
+	labels := []string{}
+	for _, block := range blocks {
+		labels = append(labels, block.Type+" "+strings.Join(block.Labels, "."))
+	}
+
+	want := []string{
+		"import ",
+		"resource sendgrid_template.welcome_email",
+		"import ",
+		"resource sendgrid_template_version.welcome_email_v1",
+		"import ",
+		"resource sendgrid_template_version.welcome_email_v1_2",
+		"import ",
+		"resource sendgrid_template.welcome_email_2",
+	}
+	if strings.Join(labels, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected blocks %v, got %v", want, labels)
+	}
+
+	for key, want := range map[string]string{
+		"html_content": templates[0].Versions[0].HTMLContent,
+		"test_data":    templates[0].Versions[0].TestData,
+		"subject":      templates[0].Versions[0].Subject,
+	} {
+		value, diags := blocks[3].Body.Attributes[key].Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatalf("%s: %v", key, diags)
+		}
+
+		if got := value.AsString(); got != want {
+			t.Errorf("%s: expected %q, got %q", key, want, got)
+		}
+	}
+
+	// The plain content of the first version is the one generated by the provider, unlike the second one.
+	if _, ok := blocks[3].Body.Attributes["plain_content"]; ok {
+		t.Error("expected the generated plain content to be left out")
+	}
+
+	if _, ok := blocks[5].Body.Attributes["plain_content"]; !ok {
+		t.Error("expected the plain content to be kept")
+	}
+
+	id, _ := blocks[4].Body.Attributes["id"].Expr.Value(nil)
+	if id.AsString() != "d-1/v-2" {
+		t.Errorf("expected the import ID of the version to be d-1/v-2, got %q", id.AsString())
+	}
+}
+
+func TestGenerateContentDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "templates")
+
+	var b strings.Builder
+
+	if err := importgen.Generate(&b, templates[:1], importgen.Options{ContentDir: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "welcome_email", "v1.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != templates[0].Versions[0].HTMLContent {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "welcome_email", "v1_2.txt")); err != nil {
+		t.Errorf("expected the plain content to be written: %v", err)
+	}
+
+	if !strings.Contains(b.String(), "html_content_file = \""+filepath.ToSlash(dir)) {
+		t.Errorf("expected html_content_file to reference the content directory:\n%s", b.String())
+	}
+}
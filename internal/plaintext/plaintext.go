@@ -0,0 +1,233 @@
+// Package plaintext generates the plain text alternative of an HTML email, deterministically,
+// so that the plain content of a template version doesn't drift when SendGrid changes its generator.
+package plaintext
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// FromHTML converts HTML to plain text: tags are stripped, block elements become paragraphs,
+// list items become "- " lines and links are kept as "text (url)".
+// Handlebars expressions are kept as they are, so that the plain text renders with the same data.
+func FromHTML(src string) string {
+	c := &converter{}
+	z := html.NewTokenizer(strings.NewReader(src))
+
+	// Reading from a string, the tokenizer only stops at the end of the input.
+	for tt := z.Next(); tt != html.ErrorToken; tt = z.Next() {
+		token := z.Token()
+
+		switch tt {
+		case html.TextToken:
+			c.text(token.Data)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			c.start(token, tt == html.SelfClosingTagToken)
+		case html.EndTagToken:
+			c.end(token)
+		case html.CommentToken, html.DoctypeToken, html.ErrorToken:
+			// Comments, including Outlook conditional comments, aren't part of the text.
+		}
+	}
+
+	return normalize(c.out.String())
+}
+
+// skippedElements are elements whose content isn't part of the text of an email.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head:     true,
+	atom.Title:    true,
+	atom.Style:    true,
+	atom.Script:   true,
+	atom.Noscript: true,
+	atom.Template: true,
+}
+
+// paragraphElements are separated from their surroundings by a blank line.
+var paragraphElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Table: true, atom.Ul: true, atom.Ol: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true,
+	atom.Center: true, atom.Pre: true, atom.Form: true, atom.Dl: true,
+}
+
+// lineElements start on a new line.
+var lineElements = map[atom.Atom]bool{
+	atom.Tr: true, atom.Li: true, atom.Dt: true, atom.Dd: true, atom.Tbody: true, atom.Thead: true, atom.Tfoot: true,
+}
+
+type link struct {
+	href  string
+	start int
+}
+
+type converter struct {
+	out   strings.Builder
+	skip  int
+	pre   int
+	links []link
+}
+
+func (c *converter) text(s string) {
+	if c.skip > 0 {
+		return
+	}
+
+	if c.pre > 0 {
+		c.out.WriteString(s)
+
+		return
+	}
+
+	c.out.WriteString(collapseSpaces(s))
+}
+
+func (c *converter) start(token html.Token, selfClosing bool) {
+	if skippedElements[token.DataAtom] {
+		if !selfClosing {
+			c.skip++
+		}
+
+		return
+	}
+
+	if c.skip > 0 {
+		return
+	}
+
+	switch {
+	case token.DataAtom == atom.Br:
+		c.out.WriteString("\n")
+	case token.DataAtom == atom.Hr:
+		c.out.WriteString("\n\n----\n\n")
+	case token.DataAtom == atom.Li:
+		c.out.WriteString("\n- ")
+	case token.DataAtom == atom.Td || token.DataAtom == atom.Th:
+		c.out.WriteString(" ")
+	case token.DataAtom == atom.Img:
+		if alt := strings.TrimSpace(attr(token, "alt")); alt != "" {
+			c.out.WriteString(" " + alt + " ")
+		}
+	case token.DataAtom == atom.A && !selfClosing:
+		c.links = append(c.links, link{href: strings.TrimSpace(attr(token, "href")), start: c.out.Len()})
+	case paragraphElements[token.DataAtom]:
+		c.out.WriteString("\n\n")
+	case lineElements[token.DataAtom]:
+		c.out.WriteString("\n")
+	}
+
+	if token.DataAtom == atom.Pre && !selfClosing {
+		c.pre++
+	}
+}
+
+func (c *converter) end(token html.Token) {
+	if skippedElements[token.DataAtom] {
+		if c.skip > 0 {
+			c.skip--
+		}
+
+		return
+	}
+
+	if c.skip > 0 {
+		return
+	}
+
+	switch {
+	case token.DataAtom == atom.A:
+		c.endLink()
+	case paragraphElements[token.DataAtom]:
+		c.out.WriteString("\n\n")
+	}
+
+	if token.DataAtom == atom.Pre && c.pre > 0 {
+		c.pre--
+	}
+}
+
+// endLink appends the URL of a link after its text, unless it would only repeat it.
+func (c *converter) endLink() {
+	if len(c.links) == 0 {
+		return
+	}
+
+	l := c.links[len(c.links)-1]
+	c.links = c.links[:len(c.links)-1]
+
+	if l.href == "" || strings.HasPrefix(l.href, "#") || strings.HasPrefix(strings.ToLower(l.href), "javascript:") {
+		return
+	}
+
+	text := strings.TrimSpace(c.out.String()[l.start:])
+	if text == l.href || "mailto:"+text == l.href || "tel:"+text == l.href {
+		return
+	}
+
+	if text == "" {
+		c.out.WriteString(l.href)
+
+		return
+	}
+
+	c.out.WriteString(" (" + l.href + ")")
+}
+
+func attr(token html.Token, key string) string {
+	for _, a := range token.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+// collapseSpaces replaces runs of whitespace, including non-breaking spaces, by a single space.
+func collapseSpaces(s string) string {
+	var b strings.Builder
+
+	space := false
+
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+
+			continue
+		}
+
+		if space {
+			b.WriteByte(' ')
+
+			space = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	if space {
+		b.WriteByte(' ')
+	}
+
+	return b.String()
+}
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaces     = regexp.MustCompile(` {2,}`)
+)
+
+// normalize trims the lines, collapses the spaces left between inline elements
+// and keeps at most one blank line between paragraphs.
+func normalize(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = spaces.ReplaceAllString(strings.TrimFunc(line, unicode.IsSpace), " ")
+	}
+
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
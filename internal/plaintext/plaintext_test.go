@@ -0,0 +1,88 @@
+package plaintext
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestFromHTML(t *testing.T) { //nolint:funlen
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			src: "<html><head><title>Welcome</title><style>p { color: red; }</style></head>" +
+				"<body><h1>Welcome!</h1>\n  <p>Thanks   for\n joining.</p><p>See you<br>soon</p></body></html>",
+			want: "Welcome!\n\nThanks for joining.\n\nSee you\nsoon",
+		},
+		{
+			name: "links",
+			src: `<p>Read the <a href="https://example.com/docs">documentation</a>, ` +
+				`<a href="https://example.com">https://example.com</a>, <a href="mailto:hi@example.com">hi@example.com</a>, ` +
+				`<a href="#top">top</a>.</p>`,
+			want: "Read the documentation (https://example.com/docs), https://example.com, hi@example.com, top.",
+		},
+		{
+			name: "lists and tables",
+			src: "<ul><li>One</li><li>Two</li></ul>" +
+				"<table><tr><td>Item</td><td>Price</td></tr><tr><td>Book</td><td>12</td></tr></table>",
+			want: "- One\n- Two\n\nItem Price\nBook 12",
+		},
+		{
+			name: "entities and images",
+			src:  "<p>Tom &amp; Jerry&nbsp;&nbsp;<img src=\"logo.png\" alt=\"ACME\"> &lt;3</p><hr><p>Bye</p>",
+			want: "Tom & Jerry ACME <3\n\n----\n\nBye",
+		},
+		{
+			name: "handlebars",
+			src: `<p>Hello {{insert first_name "default=Customer"}},</p>
+{{#if vip}}
+  <p>Your <a href="{{vip_url}}">VIP area</a> is ready.</p>
+{{else}}
+  <p>{{{promotion}}}</p>
+{{/if}}
+<ul>{{#each items}}<li>{{this.name}}</li>{{/each}}</ul>`,
+			want: "Hello {{insert first_name \"default=Customer\"}},\n\n{{#if vip}}\n\n" +
+				"Your VIP area ({{vip_url}}) is ready.\n\n{{else}}\n\n{{{promotion}}}\n\n{{/if}}\n\n" +
+				"{{#each items}}\n- {{this.name}}{{/each}}",
+		},
+		{
+			name: "comments",
+			src:  "<!--[if mso]><table><tr><td><![endif]--><p>Hi</p><!-- tracking -->",
+			want: "Hi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromHTML(tt.src); got != tt.want {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+
+			// The conversion runs at plan time for each version, possibly concurrently:
+			// it must give the same result each time, without any shared state.
+			results := make([]string, 8)
+
+			var wg sync.WaitGroup
+			for i := range results {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+
+					results[i] = FromHTML(tt.src)
+				}(i)
+			}
+
+			wg.Wait()
+
+			for _, got := range results {
+				if got != tt.want {
+					t.Errorf("expected the same conversion concurrently, got:\n%q", got)
+				}
+			}
+		})
+	}
+}
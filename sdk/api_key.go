@@ -27,8 +27,13 @@ func parseAPIKey(respBody string) (*APIKey, RequestError) {
 	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
+// APIKeys are the API keys listed by SendGrid, without their scopes.
+type APIKeys struct {
+	Result []APIKey `json:"result"`
+}
+
 func parseAPIKeys(respBody string) ([]APIKey, RequestError) {
-	var body []APIKey
+	var body APIKeys
 	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
 		return nil, RequestError{
 			StatusCode: http.StatusInternalServerError,
@@ -36,7 +41,7 @@ func parseAPIKeys(respBody string) ([]APIKey, RequestError) {
 		}
 	}
 
-	return body, RequestError{StatusCode: http.StatusOK, Err: nil}
+	return body.Result, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
 // CreateAPIKey creates an APIKey and returns it.
@@ -89,11 +94,12 @@ func (c *Client) ReadAPIKey(ctx context.Context, id string) (*APIKey, RequestErr
 	return parseAPIKey(respBody)
 }
 
+// ReadAPIKeys retrieves the API keys, with their ID and name only: SendGrid doesn't list their scopes.
 func (c *Client) ReadAPIKeys(ctx context.Context) ([]APIKey, RequestError) {
-	respBody, _, err := c.Get(ctx, "GET", "/api_keys")
+	respBody, statusCode, err := c.Get(ctx, "GET", "/api_keys")
 	if err != nil {
 		return nil, RequestError{
-			StatusCode: http.StatusInternalServerError,
+			StatusCode: statusCode,
 			Err:        err,
 		}
 	}
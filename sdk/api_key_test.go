@@ -0,0 +1,37 @@
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReadAPIKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api_keys" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if got := r.Header.Get("On-Behalf-Of"); got != "staging" {
+			t.Errorf("On-Behalf-Of = %q, want %q", got, "staging")
+		}
+
+		fmt.Fprint(w, `{"result": [{"name": "deploy", "api_key_id": "key-1"}, {"name": "monitoring", "api_key_id": "key-2"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "staging")
+
+	apiKeys, requestErr := client.ReadAPIKeys(context.Background())
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	want := []APIKey{{ID: "key-1", Name: "deploy"}, {ID: "key-2", Name: "monitoring"}}
+	if !reflect.DeepEqual(apiKeys, want) {
+		t.Errorf("ReadAPIKeys() = %+v, want %+v", apiKeys, want)
+	}
+}
@@ -24,6 +24,9 @@ type Client struct {
 	apiKey     string
 	host       string
 	OnBehalfOf string
+
+	// Usernames caches the usernames of the teammates, it's shared by the clients of a provider run.
+	Usernames *UsernameCache
 }
 
 type Response struct {
@@ -58,9 +61,38 @@ func NewClient(apiKey, host, onBehalfOf string) *Client {
 		apiKey:     apiKey,
 		host:       host,
 		OnBehalfOf: onBehalfOf,
+		Usernames:  NewUsernameCache(),
 	}
 }
 
+// impersonate returns a copy of the client making its calls on behalf of the given subuser.
+// The copy is used for a single call, so the client itself can be shared safely.
+func (c *Client) impersonate(onBehalfOf string) *Client {
+	impersonated := *c
+	impersonated.OnBehalfOf = onBehalfOf
+
+	return &impersonated
+}
+
+// pageMetadata is the metadata of the endpoints using token pagination.
+type pageMetadata struct {
+	Next string `json:"next,omitempty"`
+}
+
+// nextPageToken extracts the page_token query parameter of the next page URL given in the metadata of a page.
+func nextPageToken(next string) string {
+	if next == "" {
+		return ""
+	}
+
+	u, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("page_token")
+}
+
 func bodyToJSON(body interface{}) ([]byte, error) {
 	if body == nil {
 		return nil, ErrBodyNotNil
@@ -74,15 +106,18 @@ func bodyToJSON(body interface{}) ([]byte, error) {
 	return jsonBody, nil
 }
 
-// Get gets a resource from Sendgrid.
-func (c *Client) Get(ctx context.Context, method rest.Method, endpoint string) (string, int, error) {
-	var req rest.Request
+// newRequest prepares a request to the endpoint, made on behalf of the subuser of the client if any.
+func (c *Client) newRequest(endpoint string) rest.Request {
 	if c.OnBehalfOf != "" {
-		req = sendgrid.GetRequestSubuser(c.apiKey, endpoint, c.host, c.OnBehalfOf)
-	} else {
-		req = sendgrid.GetRequest(c.apiKey, endpoint, c.host)
+		return sendgrid.GetRequestSubuser(c.apiKey, endpoint, c.host, c.OnBehalfOf)
 	}
 
+	return sendgrid.GetRequest(c.apiKey, endpoint, c.host)
+}
+
+// Get gets a resource from Sendgrid.
+func (c *Client) Get(ctx context.Context, method rest.Method, endpoint string) (string, int, error) {
+	req := c.newRequest(endpoint)
 	req.Method = method
 
 	resp, err := sendgrid.API(req)
@@ -97,14 +132,7 @@ func (c *Client) Get(ctx context.Context, method rest.Method, endpoint string) (
 func (c *Client) Post(ctx context.Context, method rest.Method, endpoint string, body interface{}) (string, int, error) {
 	var err error
 
-	var req rest.Request
-
-	if c.OnBehalfOf != "" {
-		req = sendgrid.GetRequestSubuser(c.apiKey, endpoint, c.host, c.OnBehalfOf)
-	} else {
-		req = sendgrid.GetRequest(c.apiKey, endpoint, c.host)
-	}
-
+	req := c.newRequest(endpoint)
 	req.Method = method
 
 	if body != nil {
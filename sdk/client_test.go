@@ -68,6 +68,24 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestImpersonate(t *testing.T) {
+	client := NewClient("test-api-key", "", "parent-subuser")
+
+	impersonated := client.impersonate("other-subuser")
+	if impersonated == client {
+		t.Fatal("impersonate() returned the same client, want a copy")
+	}
+	if impersonated.OnBehalfOf != "other-subuser" {
+		t.Errorf("impersonate().OnBehalfOf = %v, want %v", impersonated.OnBehalfOf, "other-subuser")
+	}
+	if impersonated.apiKey != client.apiKey || impersonated.host != client.host {
+		t.Error("impersonate() did not keep the credentials of the client")
+	}
+	if client.OnBehalfOf != "parent-subuser" {
+		t.Errorf("impersonate() modified the client, OnBehalfOf = %v, want %v", client.OnBehalfOf, "parent-subuser")
+	}
+}
+
 func TestBodyToJSON(t *testing.T) {
 	tests := []struct {
 		name    string
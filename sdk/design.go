@@ -0,0 +1,184 @@
+package sendgrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Design is a design of the SendGrid Design Library, shared by marketing and transactional templates.
+type Design struct {
+	ID                   string   `json:"id,omitempty"`
+	Name                 string   `json:"name,omitempty"`
+	Editor               string   `json:"editor,omitempty"`
+	HTMLContent          string   `json:"html_content,omitempty"`  //nolint:tagliatelle
+	PlainContent         string   `json:"plain_content,omitempty"` //nolint:tagliatelle
+	GeneratePlainContent bool     `json:"generate_plain_content"`  //nolint:tagliatelle
+	Subject              string   `json:"subject,omitempty"`
+	Categories           []string `json:"categories"`
+	ThumbnailURL         string   `json:"thumbnail_url,omitempty"` //nolint:tagliatelle
+	CreatedAt            string   `json:"created_at,omitempty"`    //nolint:tagliatelle
+	UpdatedAt            string   `json:"updated_at,omitempty"`    //nolint:tagliatelle
+}
+
+// designPage is a page of designs, the next one is linked from its metadata.
+type designPage struct {
+	Result   []Design     `json:"result"`
+	Metadata pageMetadata `json:"_metadata"` //nolint:tagliatelle
+}
+
+func parseDesign(respBody string) (*Design, RequestError) {
+	var body Design
+
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing design: %w", err),
+		}
+	}
+
+	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// CreateDesign creates a design in the Design Library and returns it.
+func (c *Client) CreateDesign(ctx context.Context, design Design) (*Design, RequestError) {
+	if design.Name == "" {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrDesignNameRequired,
+		}
+	}
+
+	respBody, statusCode, err := c.Post(ctx, "POST", "/designs", design)
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed creating design: %w", err),
+		}
+	}
+
+	return parseDesign(respBody)
+}
+
+// ReadDesign retrieves a design of the Design Library and returns it.
+func (c *Client) ReadDesign(ctx context.Context, id string) (*Design, RequestError) {
+	if id == "" {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrDesignIDRequired,
+		}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/designs/"+id)
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading design: %w", err),
+		}
+	}
+
+	return parseDesign(respBody)
+}
+
+// UpdateDesign edits a design of the Design Library and returns it.
+func (c *Client) UpdateDesign(ctx context.Context, design Design) (*Design, RequestError) {
+	if design.ID == "" {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrDesignIDRequired,
+		}
+	}
+
+	id := design.ID
+	design.ID = ""
+	design.Editor = ""
+
+	respBody, statusCode, err := c.Post(ctx, "PATCH", "/designs/"+id, design)
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed updating design: %w", err),
+		}
+	}
+
+	return parseDesign(respBody)
+}
+
+// DeleteDesign deletes a design of the Design Library.
+func (c *Client) DeleteDesign(ctx context.Context, id string) (bool, RequestError) {
+	if id == "" {
+		return false, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrDesignIDRequired,
+		}
+	}
+
+	if _, statusCode, err := c.Get(ctx, "DELETE", "/designs/"+id); err != nil {
+		return false, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed deleting design: %w", err),
+		}
+	}
+
+	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadPrebuiltDesign retrieves a pre-built design of SendGrid and returns it.
+func (c *Client) ReadPrebuiltDesign(ctx context.Context, id string) (*Design, RequestError) {
+	if id == "" {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrDesignIDRequired,
+		}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/designs/pre-builts/"+id)
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading pre-built design: %w", err),
+		}
+	}
+
+	return parseDesign(respBody)
+}
+
+// ReadPrebuiltDesigns retrieves the summaries of all the pre-built designs of SendGrid, following the pagination.
+func (c *Client) ReadPrebuiltDesigns(ctx context.Context) ([]Design, RequestError) {
+	designs := []Design{}
+	pageToken := ""
+
+	for {
+		path := "/designs/pre-builts?page_size=100"
+		if pageToken != "" {
+			path += "&page_token=" + url.QueryEscape(pageToken)
+		}
+
+		respBody, statusCode, err := c.Get(ctx, "GET", path)
+		if err != nil {
+			return nil, RequestError{
+				StatusCode: statusCode,
+				Err:        fmt.Errorf("failed reading pre-built designs: %w", err),
+			}
+		}
+
+		var page designPage
+		if err := json.Unmarshal([]byte(respBody), &page); err != nil {
+			return nil, RequestError{
+				StatusCode: http.StatusInternalServerError,
+				Err:        fmt.Errorf("failed parsing pre-built designs: %w", err),
+			}
+		}
+
+		designs = append(designs, page.Result...)
+
+		next := nextPageToken(page.Metadata.Next)
+		if next == "" || next == pageToken || len(page.Result) == 0 {
+			return designs, RequestError{StatusCode: http.StatusOK, Err: nil}
+		}
+
+		pageToken = next
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type DomainAuthenticationDNS struct {
@@ -16,13 +17,16 @@ type DomainAuthenticationDNS struct {
 	DKIM         DomainAuthenticationDNSValue `json:"dkim,omitempty"`
 }
 
-type DomainAuthenticationDNSValue struct {
+// DNSRecordValue is a DNS record SendGrid expects to find for an authenticated domain or a branded link.
+type DNSRecordValue struct {
 	Valid bool   `json:"valid,omitempty"`
 	Type  string `json:"type,omitempty"`
 	Host  string `json:"host,omitempty"`
 	Data  string `json:"data,omitempty"`
 }
 
+type DomainAuthenticationDNSValue DNSRecordValue
+
 // DomainAuthentication is a Sendgrid domain authentication.
 type DomainAuthentication struct { //nolint:maligned
 	ID                 int32                   `json:"id,omitempty"`
@@ -145,23 +149,59 @@ func (c *Client) UpdateDomainAuthentication(
 	return ParseDomainAuthentication(respBody)
 }
 
-func (c *Client) ValidateDomainAuthentication(ctx context.Context, id string) RequestError {
+// DNSValidationResult is the validation result of a single DNS record.
+type DNSValidationResult struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// DNSValidation is the result of validating the DNS records of an authenticated domain or a branded link,
+// keyed by the record role (mail_cname, dkim1, domain_cname...).
+type DNSValidation struct {
+	ID                int32                          `json:"id,omitempty"`
+	Valid             bool                           `json:"valid"`
+	ValidationResults map[string]DNSValidationResult `json:"validation_results,omitempty"` //nolint:tagliatelle
+}
+
+func parseDNSValidation(respBody string) (*DNSValidation, RequestError) {
+	var body DNSValidation
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing DNS validation: %w", err),
+		}
+	}
+
+	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ValidateDomainAuthentication asks SendGrid to check the DNS records of an authenticated domain
+// and returns the validation result of each record.
+func (c *Client) ValidateDomainAuthentication(ctx context.Context, id string) (*DNSValidation, RequestError) {
 	if id == "" {
-		return RequestError{
+		return nil, RequestError{
 			StatusCode: http.StatusInternalServerError,
 			Err:        ErrDomainAuthenticationIDRequired,
 		}
 	}
 
-	_, statusCode, err := c.Post(ctx, "POST", "/whitelabel/domains/"+id+"/validate", nil)
-	if err != nil || statusCode != 200 {
-		return RequestError{
+	respBody, statusCode, err := c.Post(ctx, "POST", "/whitelabel/domains/"+id+"/validate", nil)
+	if err != nil {
+		return nil, RequestError{
 			StatusCode: statusCode,
 			Err:        err,
 		}
 	}
 
-	return RequestError{StatusCode: http.StatusOK, Err: nil}
+	if statusCode >= http.StatusMultipleChoices {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err: fmt.Errorf("%w, status: %d, response: %s",
+				ErrFailedValidatingDomainAuthentication, statusCode, respBody),
+		}
+	}
+
+	return parseDNSValidation(respBody)
 }
 
 // DeleteDomainAuthentication deletes an DomainAuthentication.
@@ -191,3 +231,139 @@ func (c *Client) DeleteDomainAuthentication(ctx context.Context, id string) (boo
 
 	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
+
+type subuserAssociation struct {
+	Username string `json:"username"`
+}
+
+// AssociateDomainAuthenticationWithSubuser shares an authenticated domain of the parent account with a subuser.
+// When multiple is true, the association endpoint allowing up to five domains per subuser is used.
+func (c *Client) AssociateDomainAuthenticationWithSubuser(
+	ctx context.Context,
+	id string,
+	username string,
+	multiple bool,
+) RequestError {
+	if id == "" {
+		return RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrDomainAuthenticationIDRequired,
+		}
+	}
+
+	if username == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	endpoint := "/whitelabel/domains/" + id + "/subuser"
+	if multiple {
+		endpoint += ":add"
+	}
+
+	respBody, statusCode, err := c.Post(ctx, "POST", endpoint, subuserAssociation{Username: username})
+	if err != nil {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed associating domain authentication with subuser: %w", err),
+		}
+	}
+
+	if statusCode >= http.StatusMultipleChoices {
+		return RequestError{
+			StatusCode: statusCode,
+			Err: fmt.Errorf("%w, status: %d, response: %s",
+				ErrFailedAssociatingDomainAuthentication, statusCode, respBody),
+		}
+	}
+
+	return RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadSubuserDomainAuthentications retrieves the authenticated domains associated with a subuser.
+// When multiple is true, all the domains associated through the multiple association endpoint are returned.
+func (c *Client) ReadSubuserDomainAuthentications(
+	ctx context.Context,
+	username string,
+	multiple bool,
+) ([]DomainAuthentication, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	endpoint := "/whitelabel/domains/subuser?username=" + url.QueryEscape(username)
+	if multiple {
+		endpoint = "/whitelabel/domains/subuser/all?username=" + url.QueryEscape(username)
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", endpoint)
+	if statusCode == http.StatusNotFound {
+		return nil, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subuser domain authentications: %w", err),
+		}
+	}
+
+	if !multiple {
+		auth, requestErr := ParseDomainAuthentication(respBody)
+		if requestErr.Err != nil || auth.ID == 0 {
+			return nil, requestErr
+		}
+
+		return []DomainAuthentication{*auth}, requestErr
+	}
+
+	var body []DomainAuthentication
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing domain authentications: %w", err),
+		}
+	}
+
+	return body, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// DisassociateDomainAuthenticationFromSubuser stops sharing an authenticated domain with a subuser.
+func (c *Client) DisassociateDomainAuthenticationFromSubuser(
+	ctx context.Context,
+	id string,
+	username string,
+	multiple bool,
+) (bool, RequestError) {
+	if username == "" {
+		return false, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	endpoint := "/whitelabel/domains/subuser?username=" + url.QueryEscape(username)
+
+	if multiple {
+		if id == "" {
+			return false, RequestError{
+				StatusCode: http.StatusInternalServerError,
+				Err:        ErrDomainAuthenticationIDRequired,
+			}
+		}
+
+		endpoint = "/whitelabel/domains/" + id + "/subuser:remove?username=" + url.QueryEscape(username)
+	}
+
+	responseBody, statusCode, err := c.Get(ctx, "DELETE", endpoint)
+	// A 404 of the multiple association endpoint doesn't mean the association is gone.
+	if statusCode == http.StatusNotFound && !multiple { // ignore not found
+		return true, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return false, RequestError{
+			StatusCode: statusCode,
+			Err: fmt.Errorf("%w, status: %d, response: %s",
+				ErrFailedDisassociatingDomainAuthentication, statusCode, responseBody),
+		}
+	}
+
+	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
@@ -1,7 +1,10 @@
 package sendgrid_test
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -110,3 +113,48 @@ func Test_parseDomainAuthentication(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestDisassociateDomainAuthenticationFromSubuser(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiple   bool
+		statusCode int
+		wantPath   string
+		wantErr    bool
+	}{
+		{name: "single", statusCode: http.StatusNoContent, wantPath: "/whitelabel/domains/subuser"},
+		{name: "single not found", statusCode: http.StatusNotFound, wantPath: "/whitelabel/domains/subuser"},
+		{name: "multiple", multiple: true, statusCode: http.StatusNoContent, wantPath: "/whitelabel/domains/123/subuser:remove"},
+		{
+			name:       "multiple not found",
+			multiple:   true,
+			statusCode: http.StatusNotFound,
+			wantPath:   "/whitelabel/domains/123/subuser:remove",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != tt.wantPath {
+					t.Errorf("request = %s %s, want DELETE %s", r.Method, r.URL.Path, tt.wantPath)
+				}
+
+				if got := r.URL.Query().Get("username"); got != "sub" {
+					t.Errorf("username = %q, want %q", got, "sub")
+				}
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			client := sendgrid.NewClient("test-api-key", server.URL, "")
+
+			_, requestErr := client.DisassociateDomainAuthenticationFromSubuser(context.Background(), "123", "sub", tt.multiple)
+			if (requestErr.Err != nil) != tt.wantErr {
+				t.Errorf("DisassociateDomainAuthenticationFromSubuser() error = %v, wantErr %v", requestErr.Err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -62,6 +62,15 @@ var (
 	// ErrTemplateVersionSubjectRequired error displayed when a template version subject wasn't specified.
 	ErrTemplateVersionSubjectRequired = errors.New("a template version subject is required")
 
+	// ErrDesignIDRequired error displayed when a design ID wasn't specified.
+	ErrDesignIDRequired = errors.New("a design ID is required")
+
+	// ErrDesignNameRequired error displayed when a design name wasn't specified.
+	ErrDesignNameRequired = errors.New("a design name is required")
+
+	// ErrMailRecipientRequired error displayed when an email has no recipient.
+	ErrMailRecipientRequired = errors.New("a mail recipient is required")
+
 	ErrFailedCreatingUnsubscribeGroup = errors.New("failed to create unsubscribe list")
 
 	ErrUnsubscribeGroupIDRequired = errors.New("unsubscribe list id is required")
@@ -84,15 +93,39 @@ var (
 
 	ErrFailedDeletingDomainAuthentication = errors.New("failed deleting domain authentication")
 
+	ErrFailedAssociatingDomainAuthentication = errors.New("failed associating domain authentication with subuser")
+
+	ErrFailedDisassociatingDomainAuthentication = errors.New("failed disassociating domain authentication from subuser")
+
+	ErrFailedValidatingDomainAuthentication = errors.New("failed validating domain authentication")
+
 	ErrLinkBrandingIDRequired = errors.New("link branding id is required")
 
 	ErrFailedDeletingLinkBranding = errors.New("failed to delete link branding")
 
 	ErrFailedCreatingLinkBranding = errors.New("failed to create link branding")
 
+	ErrFailedAssociatingLinkBranding = errors.New("failed associating link branding with subuser")
+
+	ErrFailedDisassociatingLinkBranding = errors.New("failed disassociating link branding from subuser")
+
+	ErrFailedValidatingLinkBranding = errors.New("failed validating link branding")
+
 	// ErrSubUserPassword should be empty.
 	ErrSubUserPassword = errors.New("new password must be non empty")
 
+	// ErrSubUserOldPassword error displayed when the current password of a subUser wasn't specified.
+	ErrSubUserOldPassword = errors.New("current password must be non empty")
+
+	// ErrSubUserCreditTypeRequired error displayed when a subUser credit allocation type wasn't specified.
+	ErrSubUserCreditTypeRequired = errors.New("a credit allocation type is required")
+
+	// ErrFailedUpdatingSubUserCredits error displayed when the provider can not update the credits of a subuser.
+	ErrFailedUpdatingSubUserCredits = errors.New("failed updating subUser credits")
+
+	// ErrFailedUpdatingSubUserMonitor error displayed when the provider can not set the monitor of a subuser.
+	ErrFailedUpdatingSubUserMonitor = errors.New("failed updating subUser monitor")
+
 	// ErrSSOIntegrationMissingField error displayed when a required SSO integration field is not specified.
 	ErrSSOIntegrationMissingField = errors.New("SSO integration field is missing")
 
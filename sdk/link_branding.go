@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type LinkBrandingDNS struct {
@@ -12,12 +13,7 @@ type LinkBrandingDNS struct {
 	OwnerCNAME  LinkBrandingDNSValue `json:"owner_cname,omitempty"`  //nolint:tagliatelle
 }
 
-type LinkBrandingDNSValue struct {
-	Valid bool   `json:"valid,omitempty"`
-	Type  string `json:"type,omitempty"`
-	Host  string `json:"host,omitempty"`
-	Data  string `json:"data,omitempty"`
-}
+type LinkBrandingDNSValue DNSRecordValue
 
 // LinkBranding is a Sendgrid domain authentication.
 type LinkBranding struct {
@@ -118,23 +114,33 @@ func (c *Client) UpdateLinkBranding(ctx context.Context, id string, isDefault bo
 	return parseLinkBranding(respBody)
 }
 
-func (c *Client) ValidateLinkBranding(ctx context.Context, id string) RequestError {
+// ValidateLinkBranding asks SendGrid to check the DNS records of a branded link
+// and returns the validation result of each record.
+func (c *Client) ValidateLinkBranding(ctx context.Context, id string) (*DNSValidation, RequestError) {
 	if id == "" {
-		return RequestError{
+		return nil, RequestError{
 			StatusCode: http.StatusInternalServerError,
 			Err:        ErrLinkBrandingIDRequired,
 		}
 	}
 
-	_, statusCode, err := c.Post(ctx, "POST", "/whitelabel/links/"+id+"/validate", nil)
-	if err != nil || statusCode != 200 {
-		return RequestError{
+	respBody, statusCode, err := c.Post(ctx, "POST", "/whitelabel/links/"+id+"/validate", nil)
+	if err != nil {
+		return nil, RequestError{
 			StatusCode: statusCode,
 			Err:        err,
 		}
 	}
 
-	return RequestError{StatusCode: http.StatusOK, Err: nil}
+	if statusCode >= http.StatusMultipleChoices {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err: fmt.Errorf("%w, status: %d, response: %s",
+				ErrFailedValidatingLinkBranding, statusCode, respBody),
+		}
+	}
+
+	return parseDNSValidation(respBody)
 }
 
 // DeleteLinkBranding deletes an LinkBranding.
@@ -163,3 +169,83 @@ func (c *Client) DeleteLinkBranding(ctx context.Context, id string) (bool, Reque
 
 	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
+
+// AssociateLinkBrandingWithSubuser shares a branded link of the parent account with a subuser.
+func (c *Client) AssociateLinkBrandingWithSubuser(ctx context.Context, id string, username string) RequestError {
+	if id == "" {
+		return RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrLinkBrandingIDRequired,
+		}
+	}
+
+	if username == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Post(ctx, "POST", "/whitelabel/links/"+id+"/subuser", subuserAssociation{
+		Username: username,
+	})
+	if err != nil {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed associating link branding with subuser: %w", err),
+		}
+	}
+
+	if statusCode >= http.StatusMultipleChoices {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("%w, status: %d, response: %s", ErrFailedAssociatingLinkBranding, statusCode, respBody),
+		}
+	}
+
+	return RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadSubuserLinkBranding retrieves the branded link associated with a subuser, nil if there is none.
+func (c *Client) ReadSubuserLinkBranding(ctx context.Context, username string) (*LinkBranding, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/whitelabel/links/subuser?username="+url.QueryEscape(username))
+	if statusCode == http.StatusNotFound {
+		return nil, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subuser link branding: %w", err),
+		}
+	}
+
+	link, requestErr := parseLinkBranding(respBody)
+	if requestErr.Err != nil || link.ID == 0 {
+		return nil, requestErr
+	}
+
+	return link, requestErr
+}
+
+// DisassociateLinkBrandingFromSubuser stops sharing the branded link associated with a subuser.
+func (c *Client) DisassociateLinkBrandingFromSubuser(ctx context.Context, username string) (bool, RequestError) {
+	if username == "" {
+		return false, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	responseBody, statusCode, err := c.Get(ctx, "DELETE", "/whitelabel/links/subuser?username="+url.QueryEscape(username))
+	if statusCode == http.StatusNotFound { // ignore not found
+		return true, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return false, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("%w, status: %d, response: %s", ErrFailedDisassociatingLinkBranding, statusCode, responseBody),
+		}
+	}
+
+	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
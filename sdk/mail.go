@@ -0,0 +1,95 @@
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/sendgrid/sendgrid-go"
+)
+
+// Mail is an email sent with the Mail Send API.
+type Mail struct {
+	Personalizations []MailPersonalization `json:"personalizations"`
+	From             MailAddress           `json:"from"`
+	Subject          string                `json:"subject,omitempty"`
+	Content          []MailContent         `json:"content,omitempty"`
+	TemplateID       string                `json:"template_id,omitempty"` //nolint:tagliatelle
+	Categories       []string              `json:"categories,omitempty"`
+	CustomArgs       map[string]string     `json:"custom_args,omitempty"`   //nolint:tagliatelle
+	MailSettings     *MailSettings         `json:"mail_settings,omitempty"` //nolint:tagliatelle
+}
+
+// MailPersonalization is a recipient of an email and the data of its template.
+type MailPersonalization struct {
+	To                  []MailAddress `json:"to"`
+	DynamicTemplateData interface{}   `json:"dynamic_template_data,omitempty"` //nolint:tagliatelle
+}
+
+// MailAddress is the address and the name of a sender or a recipient.
+type MailAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// MailContent is a content of an email, by MIME type.
+type MailContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// MailSettings are the settings of an email.
+type MailSettings struct {
+	SandboxMode *MailSetting `json:"sandbox_mode,omitempty"` //nolint:tagliatelle
+}
+
+// MailSetting enables a setting of an email.
+type MailSetting struct {
+	Enable bool `json:"enable"`
+}
+
+// SendMail sends an email and returns the ID SendGrid gave to the message,
+// which is empty in sandbox mode since no message is sent.
+func (c *Client) SendMail(ctx context.Context, mail Mail) (string, RequestError) {
+	if len(mail.Personalizations) == 0 {
+		return "", RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        ErrMailRecipientRequired,
+		}
+	}
+
+	body, err := bodyToJSON(mail)
+	if err != nil {
+		return "", RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed preparing request body: %w", err),
+		}
+	}
+
+	req := c.newRequest("/mail/send")
+	req.Method = "POST"
+	req.Body = body
+
+	// The ID of the message is only given in the headers of the response, which Post doesn't return.
+	resp, err := sendgrid.API(req)
+	if err != nil {
+		return "", RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed sending mail: %w", err),
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("failed sending mail: api response: HTTP %d: %s", resp.StatusCode, resp.Body),
+		}
+	}
+
+	messageID := ""
+	if ids := resp.Headers["X-Message-Id"]; len(ids) > 0 {
+		messageID = ids[0]
+	}
+
+	return messageID, RequestError{StatusCode: resp.StatusCode, Err: nil}
+}
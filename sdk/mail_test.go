@@ -0,0 +1,56 @@
+package sendgrid
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendMail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/mail/send" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if got := r.Header.Get("On-Behalf-Of"); got != "staging" {
+			t.Errorf("On-Behalf-Of = %q, want %q", got, "staging")
+		}
+
+		var mail Mail
+		if err := json.NewDecoder(r.Body).Decode(&mail); err != nil {
+			t.Fatal(err)
+		}
+
+		if mail.TemplateID != "d-1" || mail.Personalizations[0].To[0].Email != "seed@example.com" {
+			t.Errorf("unexpected mail %+v", mail)
+		}
+
+		w.Header().Set("X-Message-Id", "message-1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "staging")
+
+	messageID, requestErr := client.SendMail(context.Background(), Mail{
+		Personalizations: []MailPersonalization{{
+			To:                  []MailAddress{{Email: "seed@example.com"}},
+			DynamicTemplateData: map[string]interface{}{"first_name": "Ada"},
+		}},
+		From:       MailAddress{Email: "test@example.com"},
+		TemplateID: "d-1",
+	})
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if messageID != "message-1" {
+		t.Errorf("SendMail() = %q, want %q", messageID, "message-1")
+	}
+
+	if _, requestErr := client.SendMail(context.Background(), Mail{}); requestErr.Err == nil {
+		t.Error("expected an error without recipient")
+	}
+}
@@ -0,0 +1,35 @@
+package sendgrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Scopes are the scopes of the API key making the calls.
+type Scopes struct {
+	Scopes []string `json:"scopes"`
+}
+
+// ReadScopes retrieves the scopes of the API key making the calls,
+// which are the scopes it can grant to API keys and teammates.
+func (c *Client) ReadScopes(ctx context.Context) ([]string, RequestError) {
+	respBody, statusCode, err := c.Get(ctx, "GET", "/scopes")
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading scopes: %w", err),
+		}
+	}
+
+	var body Scopes
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing scopes: %w", err),
+		}
+	}
+
+	return body.Scopes, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
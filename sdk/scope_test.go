@@ -0,0 +1,32 @@
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReadScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/scopes" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{"scopes": ["mail.send", "templates.read"]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "")
+
+	scopes, requestErr := client.ReadScopes(context.Background())
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if want := []string{"mail.send", "templates.read"}; !reflect.DeepEqual(scopes, want) {
+		t.Errorf("ReadScopes() = %v, want %v", scopes, want)
+	}
+}
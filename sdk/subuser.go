@@ -7,25 +7,71 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+
+	"github.com/sendgrid/rest"
 )
 
-type creditAllocation struct {
-	Type string `json:"credit_allocation,omitempty"` //nolint:tagliatelle
+// SubUserCredits is the credit allocation of a Sendgrid SubUser.
+type SubUserCredits struct {
+	Type           string `json:"type,omitempty"`
+	ResetFrequency string `json:"reset_frequency,omitempty"` //nolint:tagliatelle
+	Total          int    `json:"total,omitempty"`
+	Remaining      int    `json:"remain,omitempty"`
+	Used           int    `json:"used,omitempty"`
+}
+
+// SubUserMonitor is the monitor configuration of a Sendgrid SubUser.
+// A sample of the subuser's emails is sent to Email every Frequency emails.
+type SubUserMonitor struct {
+	Email     string `json:"email"`
+	Frequency int    `json:"frequency"`
+}
+
+// SubUserReputation is the sender reputation of a Sendgrid SubUser.
+type SubUserReputation struct {
+	Username   string  `json:"username"`
+	Reputation float64 `json:"reputation"`
+}
+
+// SubUserStatsMetrics are the email statistics of a Sendgrid SubUser over a period.
+type SubUserStatsMetrics struct {
+	Requests     int `json:"requests"`
+	Delivered    int `json:"delivered"`
+	Bounces      int `json:"bounces"`
+	Blocks       int `json:"blocks"`
+	Opens        int `json:"opens"`
+	UniqueOpens  int `json:"unique_opens"` //nolint:tagliatelle
+	Clicks       int `json:"clicks"`
+	UniqueClicks int `json:"unique_clicks"` //nolint:tagliatelle
+	SpamReports  int `json:"spam_reports"`  //nolint:tagliatelle
+	Unsubscribes int `json:"unsubscribes"`
+}
+
+type subUserMonthlyStats struct {
+	Date  string `json:"date"`
+	Stats []struct {
+		Name    string              `json:"name"`
+		Metrics SubUserStatsMetrics `json:"metrics"`
+	} `json:"stats"`
+}
+
+type subUserWebsiteAccess struct {
+	Disabled bool `json:"disabled"`
 }
 
 // SubUser is a Sendgrid SubUser.
 type SubUser struct {
-	ID                 int              `json:"id,omitempty"`
-	UserID             int              `json:"user_id,omitempty"` //nolint:tagliatelle
-	UserName           string           `json:"username,omitempty"`
-	Password           string           `json:"password,omitempty"`
-	ConfirmPassword    string           `json:"confirm_password,omitempty"` //nolint:tagliatelle
-	Email              string           `json:"email,omitempty"`
-	IPs                []string         `json:"ips,omitempty"`
-	Disabled           bool             `json:"disabled,omitempty"`
-	SignupSessionToken string           `json:"signup_session_token,omitempty"` //nolint:tagliatelle
-	AuthorizationToken string           `json:"authorization_token,omitempty"`  //nolint:tagliatelle
-	CreditAllocation   creditAllocation `json:"credit_allocation,omitempty"`    //nolint:tagliatelle
+	ID                 int             `json:"id,omitempty"`
+	UserID             int             `json:"user_id,omitempty"` //nolint:tagliatelle
+	UserName           string          `json:"username,omitempty"`
+	Password           string          `json:"password,omitempty"`
+	ConfirmPassword    string          `json:"confirm_password,omitempty"` //nolint:tagliatelle
+	Email              string          `json:"email,omitempty"`
+	IPs                []string        `json:"ips,omitempty"`
+	Disabled           bool            `json:"disabled,omitempty"`
+	SignupSessionToken string          `json:"signup_session_token,omitempty"` //nolint:tagliatelle
+	AuthorizationToken string          `json:"authorization_token,omitempty"`  //nolint:tagliatelle
+	CreditAllocation   *SubUserCredits `json:"credit_allocation,omitempty"`    //nolint:tagliatelle
 }
 
 type UpdateSubUserPassword struct {
@@ -189,22 +235,28 @@ func (c *Client) DeleteSubuser(ctx context.Context, username string) (bool, Requ
 	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
+// UpdateSubuserPassword changes the password of a subuser.
+// SendGrid requires the current password of the subuser to set a new one.
 func (c *Client) UpdateSubuserPassword(ctx context.Context, username string, oldPassword string, newPassword string) RequestError {
+	if username == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
 	if newPassword == "" {
 		return RequestError{StatusCode: http.StatusBadRequest, Err: ErrSubUserPassword}
 	}
 
-	origOnBehalfOf := c.OnBehalfOf
-	c.OnBehalfOf = username
-	_, statusCode, err := c.Post(ctx, "PUT", "/user/password", UpdateSubUserPassword{
+	if oldPassword == "" {
+		return RequestError{StatusCode: http.StatusBadRequest, Err: ErrSubUserOldPassword}
+	}
+
+	respBody, statusCode, err := c.impersonate(username).Post(ctx, "PUT", "/user/password", UpdateSubUserPassword{
 		NewPassword: newPassword,
 		OldPassword: oldPassword,
 	})
-	c.OnBehalfOf = origOnBehalfOf
-
 	if err != nil {
 		return RequestError{
-			StatusCode: http.StatusInternalServerError,
+			StatusCode: statusCode,
 			Err:        fmt.Errorf("failed updating subUser password: %w", err),
 		}
 	}
@@ -212,9 +264,272 @@ func (c *Client) UpdateSubuserPassword(ctx context.Context, username string, old
 	if statusCode >= http.StatusMultipleChoices {
 		return RequestError{
 			StatusCode: statusCode,
-			Err:        fmt.Errorf("%w: statusCode: %d", err, statusCode),
+			Err:        fmt.Errorf("failed updating subUser password, status: %d, response: %s", statusCode, respBody),
+		}
+	}
+
+	return RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadSubuserCredits retrieves the credit allocation of a subuser.
+func (c *Client) ReadSubuserCredits(ctx context.Context, username string) (*SubUserCredits, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/subusers/"+username+"/credits")
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subUser credits: %w", err),
+		}
+	}
+
+	return parseSubUserCredits(respBody)
+}
+
+// UpdateSubuserCredits sets the credit allocation of a subuser.
+func (c *Client) UpdateSubuserCredits(ctx context.Context, username string, credits SubUserCredits) (*SubUserCredits, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	if credits.Type == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrSubUserCreditTypeRequired}
+	}
+
+	respBody, statusCode, err := c.Post(ctx, "PUT", "/subusers/"+username+"/credits", SubUserCredits{
+		Type:           credits.Type,
+		ResetFrequency: credits.ResetFrequency,
+		Total:          credits.Total,
+	})
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed updating subUser credits: %w", err),
+		}
+	}
+
+	if statusCode >= http.StatusMultipleChoices {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("%w, status: %d, response: %s", ErrFailedUpdatingSubUserCredits, statusCode, respBody),
+		}
+	}
+
+	return parseSubUserCredits(respBody)
+}
+
+func parseSubUserCredits(respBody string) (*SubUserCredits, RequestError) {
+	var body SubUserCredits
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		log.Printf("[DEBUG] [parseSubUserCredits] failed parsing subUser credits, response body: %s", respBody)
+
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        err,
+		}
+	}
+
+	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadSubuserMonitor retrieves the monitor settings of a subuser.
+// A nil monitor is returned when none is configured.
+func (c *Client) ReadSubuserMonitor(ctx context.Context, username string) (*SubUserMonitor, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/subusers/"+username+"/monitor")
+	if statusCode == http.StatusNotFound {
+		return nil, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subUser monitor: %w", err),
+		}
+	}
+
+	var body SubUserMonitor
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing subUser monitor: %w", err),
+		}
+	}
+
+	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// CreateSubuserMonitor creates the monitor settings of a subuser, or replaces them when replace is true.
+func (c *Client) CreateSubuserMonitor(ctx context.Context, username string, monitor SubUserMonitor, replace bool) RequestError {
+	if username == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	if monitor.Email == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrEmailRequired}
+	}
+
+	method := "POST"
+	if replace {
+		method = "PUT"
+	}
+
+	respBody, statusCode, err := c.Post(ctx, rest.Method(method), "/subusers/"+username+"/monitor", monitor)
+	if err != nil {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed setting subUser monitor: %w", err),
+		}
+	}
+
+	if statusCode >= http.StatusMultipleChoices {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("%w, status: %d, response: %s", ErrFailedUpdatingSubUserMonitor, statusCode, respBody),
+		}
+	}
+
+	return RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// DeleteSubuserMonitor removes the monitor settings of a subuser.
+func (c *Client) DeleteSubuserMonitor(ctx context.Context, username string) RequestError {
+	if username == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "DELETE", "/subusers/"+username+"/monitor")
+	if statusCode == http.StatusNotFound {
+		return RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed deleting subUser monitor: %w, response: %s", err, respBody),
 		}
 	}
 
 	return RequestError{StatusCode: http.StatusOK, Err: nil}
 }
+
+// ReadSubuserWebsiteAccess returns whether the access of a subuser to the SendGrid website is disabled.
+// A nil value is returned when the API doesn't report it.
+func (c *Client) ReadSubuserWebsiteAccess(ctx context.Context, username string) (*bool, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/subusers/"+username+"/website_access")
+	if statusCode == http.StatusNotFound || statusCode == http.StatusMethodNotAllowed {
+		return nil, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subUser website access: %w", err),
+		}
+	}
+
+	var access subUserWebsiteAccess
+	if err := json.Unmarshal([]byte(respBody), &access); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing subUser website access: %w", err),
+		}
+	}
+
+	return &access.Disabled, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// UpdateSubuserWebsiteAccess enables/disables the access of a subuser to the SendGrid website.
+func (c *Client) UpdateSubuserWebsiteAccess(ctx context.Context, username string, disabled bool) RequestError {
+	if username == "" {
+		return RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	_, statusCode, err := c.Post(ctx, "PATCH", "/subusers/"+username+"/website_access", subUserWebsiteAccess{
+		Disabled: disabled,
+	})
+	if err != nil {
+		return RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed updating subUser website access: %w", err),
+		}
+	}
+
+	return RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadSubuserReputation retrieves the sender reputation of a subuser.
+func (c *Client) ReadSubuserReputation(ctx context.Context, username string) (*SubUserReputation, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	respBody, statusCode, err := c.Get(ctx, "GET", "/subusers/reputations?usernames="+url.QueryEscape(username))
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subUser reputation: %w", err),
+		}
+	}
+
+	var body []SubUserReputation
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing subUser reputation: %w", err),
+		}
+	}
+
+	for i := range body {
+		if body[i].Username == username {
+			return &body[i], RequestError{StatusCode: http.StatusOK, Err: nil}
+		}
+	}
+
+	return &SubUserReputation{Username: username}, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadSubuserMonthlyStats retrieves the email statistics of a subuser for the month containing date (YYYY-MM-DD).
+func (c *Client) ReadSubuserMonthlyStats(ctx context.Context, username, date string) (*SubUserStatsMetrics, RequestError) {
+	if username == "" {
+		return nil, RequestError{StatusCode: http.StatusNotAcceptable, Err: ErrUsernameRequired}
+	}
+
+	endpoint := "/subusers/" + username + "/stats/monthly?date=" + url.QueryEscape(date)
+
+	respBody, statusCode, err := c.Get(ctx, "GET", endpoint)
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed reading subUser stats: %w", err),
+		}
+	}
+
+	var body subUserMonthlyStats
+	if err := json.Unmarshal([]byte(respBody), &body); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing subUser stats: %w", err),
+		}
+	}
+
+	metrics := &SubUserStatsMetrics{}
+	for _, stat := range body.Stats {
+		if stat.Name == username {
+			metrics = &stat.Metrics
+
+			break
+		}
+	}
+
+	return metrics, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
@@ -0,0 +1,54 @@
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadSubuserWebsiteAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       *bool
+		wantErr    bool
+	}{
+		{name: "disabled", statusCode: http.StatusOK, body: `{"disabled": true}`, want: boolPtr(true)},
+		{name: "enabled", statusCode: http.StatusOK, body: `{"disabled": false}`, want: boolPtr(false)},
+		{name: "not found", statusCode: http.StatusNotFound, body: `{"errors": [{"message": "not found"}]}`},
+		{name: "method not allowed", statusCode: http.StatusMethodNotAllowed, body: `{"errors": [{"message": "method not allowed"}]}`},
+		{name: "server error", statusCode: http.StatusInternalServerError, body: `{"errors": [{"message": "internal error"}]}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/subusers/sub/website_access" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+
+				w.WriteHeader(tt.statusCode)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			client := NewClient("test-api-key", server.URL, "")
+
+			got, requestErr := client.ReadSubuserWebsiteAccess(context.Background(), "sub")
+			if (requestErr.Err != nil) != tt.wantErr {
+				t.Fatalf("ReadSubuserWebsiteAccess() error = %v, wantErr %v", requestErr.Err, tt.wantErr)
+			}
+
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ReadSubuserWebsiteAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 )
 
 type User struct {
@@ -26,12 +27,126 @@ type User struct {
 	IsSSO     bool     `json:"is_sso,omitempty"`
 	UserType  string   `json:"user_type,omitempty"`
 	Scopes    []string `json:"scopes,omitempty"`
+
+	HasRestrictedSubuserAccess bool            `json:"has_restricted_subuser_access,omitempty"`
+	SubuserAccess              []SubuserAccess `json:"subuser_access,omitempty"`
+
+	// ExpirationDate is the Unix time at which the invitation of a pending user expires.
+	ExpirationDate int64 `json:"expiration_date,omitempty"`
+}
+
+// SubuserAccess is the access of a teammate to a subuser: admin, or restricted to some scopes.
+type SubuserAccess struct {
+	ID             int      `json:"id"`
+	Username       string   `json:"username,omitempty"`
+	Email          string   `json:"email,omitempty"`
+	Disabled       bool     `json:"disabled,omitempty"`
+	PermissionType string   `json:"permission_type"`
+	Scopes         []string `json:"scopes"`
+}
+
+// TeammateSubuserAccess restricts a teammate to some subusers, or lifts the restriction when there are none.
+type TeammateSubuserAccess struct {
+	Subusers []SubuserAccess
+}
+
+// teammateRequest is the body of the requests creating and updating teammates.
+// The access to the subusers is only sent when it's given, so that it's otherwise left unchanged.
+type teammateRequest struct {
+	User
+	HasRestrictedSubuserAccess *bool           `json:"has_restricted_subuser_access,omitempty"`
+	SubuserAccess              []SubuserAccess `json:"subuser_access,omitempty"`
+}
+
+func newTeammateRequest(user User, access *TeammateSubuserAccess) teammateRequest {
+	req := teammateRequest{User: user}
+
+	if access != nil {
+		restricted := len(access.Subusers) > 0
+		req.HasRestrictedSubuserAccess = &restricted
+		req.SubuserAccess = access.Subusers
+	}
+
+	return req
 }
 
 type Users struct {
 	Result []User `json:"result"`
 }
 
+// UsernameCache caches the usernames of the teammates by email, so that the teammates are listed
+// once per provider run rather than once per read. It can be shared by several clients.
+type UsernameCache struct {
+	mutex sync.Mutex
+
+	// usernames are the usernames by email of the teammates listed on behalf of each subuser.
+	usernames map[string]map[string]string
+}
+
+// NewUsernameCache creates an empty UsernameCache.
+func NewUsernameCache() *UsernameCache {
+	return &UsernameCache{usernames: map[string]map[string]string{}}
+}
+
+// lookup returns the username of a teammate, and whether the teammates were listed at all.
+func (c *UsernameCache) lookup(onBehalfOf, email string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	usernames, listed := c.usernames[onBehalfOf]
+
+	return usernames[email], listed
+}
+
+// store replaces the usernames with the ones of a listing of the teammates.
+func (c *UsernameCache) store(onBehalfOf string, users []User) {
+	if c == nil {
+		return
+	}
+
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		if user.Username != "" {
+			usernames[user.Email] = user.Username
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.usernames[onBehalfOf] = usernames
+}
+
+// add adds the username of a teammate to the listing, if there's one.
+func (c *UsernameCache) add(onBehalfOf, email, username string) {
+	if c == nil || username == "" {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if usernames, listed := c.usernames[onBehalfOf]; listed {
+		usernames[email] = username
+	}
+}
+
+// forget removes a teammate which doesn't exist anymore.
+func (c *UsernameCache) forget(onBehalfOf, email string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.usernames[onBehalfOf], email)
+}
+
 type PendingUser struct {
 	Result []struct {
 		PendingID      string   `json:"pending_id,omitempty"`
@@ -39,7 +154,7 @@ type PendingUser struct {
 		Email          string   `json:"email,omitempty"`
 		IsAdmin        bool     `json:"is_admin,omitempty"`
 		IsReadOnly     bool     `json:"is_read_only,omitempty"`
-		ExpirationDate int      `json:"expiration_date,omitempty"`
+		ExpirationDate int64    `json:"expiration_date,omitempty"`
 		Scopes         []string `json:"scopes,omitempty"`
 	} `json:"result"`
 }
@@ -58,43 +173,128 @@ func parseUser(respBody string) (*User, RequestError) {
 	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
+// GetUsernameByEmail returns the username of an active teammate.
+// The teammates are listed once, then their usernames are taken from the cache of the client.
 func (c *Client) GetUsernameByEmail(ctx context.Context, email string) (string, RequestError) {
+	username, listed := c.Usernames.lookup(c.OnBehalfOf, email)
+	if !listed {
+		if _, requestErr := c.ReadUsers(ctx); requestErr.Err != nil {
+			return "", requestErr
+		}
+
+		username, _ = c.Usernames.lookup(c.OnBehalfOf, email)
+	}
+
+	if username != "" {
+		return username, RequestError{StatusCode: http.StatusOK, Err: nil}
+	}
+
+	return "", RequestError{
+		StatusCode: http.StatusNotFound,
+		Err:        fmt.Errorf("username with email %s not found", email),
+	}
+}
+
+// ReadUsers lists all the active teammates with a single call, and caches their usernames.
+// The list doesn't include the scopes of the teammates, ReadUserByUsername does.
+func (c *Client) ReadUsers(ctx context.Context) ([]User, RequestError) {
 	respBody, statusCode, err := c.Get(ctx, "GET", "/teammates?limit=10000")
 	if err != nil {
-		return "", RequestError{
+		return nil, RequestError{
 			StatusCode: statusCode,
 			Err:        err,
 		}
 	}
 
 	users := &Users{}
+	if err := json.Unmarshal([]byte(respBody), users); err != nil {
+		return nil, RequestError{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("failed parsing teammates: %w", err),
+		}
+	}
 
-	decoder := json.NewDecoder(bytes.NewReader([]byte(respBody)))
-	err = decoder.Decode(users)
+	c.Usernames.store(c.OnBehalfOf, users.Result)
+
+	return users.Result, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadPendingUsers lists all the pending invitations with a single call, as users of the pending type.
+func (c *Client) ReadPendingUsers(ctx context.Context) ([]User, RequestError) {
+	respBody, statusCode, err := c.Get(ctx, "GET", "/teammates/pending?limit=10000")
 	if err != nil {
-		return "", RequestError{
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed to get pending users: %w", err),
+		}
+	}
+
+	pendingUsers := &PendingUser{}
+	if err := json.Unmarshal([]byte(respBody), pendingUsers); err != nil {
+		return nil, RequestError{
 			StatusCode: http.StatusInternalServerError,
-			Err:        err,
+			Err:        fmt.Errorf("failed to decode pending users response: %w", err),
 		}
 	}
 
-	for _, user := range users.Result {
-		if user.Email == email && user.Username != "" {
-			return user.Username, RequestError{StatusCode: http.StatusOK, Err: nil}
+	users := make([]User, 0, len(pendingUsers.Result))
+	for _, pendingUser := range pendingUsers.Result {
+		users = append(users, User{
+			Email:          pendingUser.Email,
+			IsAdmin:        pendingUser.IsAdmin,
+			Scopes:         pendingUser.Scopes,
+			ExpirationDate: pendingUser.ExpirationDate,
+			UserType:       "pending",
+		})
+	}
+
+	return users, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
+
+// ReadUserByUsername reads an active teammate, with its scopes, without listing the teammates.
+func (c *Client) ReadUserByUsername(ctx context.Context, username string) (*User, RequestError) {
+	respBody, statusCode, err := c.Get(ctx, "GET", "/teammates/"+username)
+	if err != nil {
+		return nil, RequestError{
+			StatusCode: statusCode,
+			Err:        err,
 		}
 	}
-	return "", RequestError{
-		StatusCode: http.StatusNotFound,
-		Err:        fmt.Errorf("username with email %s not found", email),
+
+	return parseUser(respBody)
+}
+
+// ReadUserWithUsername reads a teammate directly by its username when it's known,
+// and falls back to ReadUser when it isn't, or when it doesn't belong to the teammate anymore.
+func (c *Client) ReadUserWithUsername(ctx context.Context, email, username string) (*User, RequestError) {
+	if username != "" {
+		user, requestErr := c.ReadUserByUsername(ctx, username)
+		if requestErr.Err == nil && user.Email == email {
+			c.Usernames.add(c.OnBehalfOf, email, username)
+
+			return user, requestErr
+		}
+
+		if requestErr.Err != nil && requestErr.StatusCode != http.StatusNotFound {
+			return nil, requestErr
+		}
 	}
+
+	return c.ReadUser(ctx, email)
 }
 
-func (c *Client) CreateUser(ctx context.Context, email string, scopes []string, isAdmin bool) (*User, RequestError) {
-	respBody, statusCode, err := c.Post(ctx, "POST", "/teammates", User{
+func (c *Client) CreateUser(
+	ctx context.Context,
+	email string,
+	scopes []string,
+	isAdmin bool,
+	access *TeammateSubuserAccess,
+) (*User, RequestError) {
+	respBody, statusCode, err := c.Post(ctx, "POST", "/teammates", newTeammateRequest(User{
 		Email:   email,
 		IsAdmin: isAdmin,
 		Scopes:  scopes,
-	})
+	}, access))
 	if err != nil {
 		return nil, RequestError{
 			StatusCode: statusCode,
@@ -102,17 +302,28 @@ func (c *Client) CreateUser(ctx context.Context, email string, scopes []string,
 		}
 	}
 
-	return parseUser(respBody)
+	user, requestErr := parseUser(respBody)
+	if requestErr.Err == nil {
+		c.Usernames.add(c.OnBehalfOf, user.Email, user.Username)
+	}
+
+	return user, requestErr
 }
 
-func (c *Client) CreateSSOUser(ctx context.Context, firstName, lastName, email string, scopes []string, isAdmin bool) (*User, RequestError) {
-	respBody, statusCode, err := c.Post(ctx, "POST", "/sso/teammates", User{
+func (c *Client) CreateSSOUser(
+	ctx context.Context,
+	firstName, lastName, email string,
+	scopes []string,
+	isAdmin bool,
+	access *TeammateSubuserAccess,
+) (*User, RequestError) {
+	respBody, statusCode, err := c.Post(ctx, "POST", "/sso/teammates", newTeammateRequest(User{
 		FirstName: firstName,
 		LastName:  lastName,
 		Email:     email,
 		IsAdmin:   isAdmin,
 		Scopes:    scopes,
-	})
+	}, access))
 	if err != nil {
 		return nil, RequestError{
 			StatusCode: statusCode,
@@ -120,56 +331,59 @@ func (c *Client) CreateSSOUser(ctx context.Context, firstName, lastName, email s
 		}
 	}
 
-	return parseUser(respBody)
+	user, requestErr := parseUser(respBody)
+	if requestErr.Err == nil {
+		c.Usernames.add(c.OnBehalfOf, user.Email, user.Username)
+	}
+
+	return user, requestErr
 }
 
 func (c *Client) ReadUser(ctx context.Context, email string) (*User, RequestError) {
 	username, requestErr := c.GetUsernameByEmail(ctx, email)
-	if requestErr.Err != nil {
-		// If user not found in active teammates, check pending invitations
-		if requestErr.StatusCode == http.StatusNotFound {
-			pendingUser, pendingErr := c.ReadPendingUser(ctx, email)
-			if pendingErr.Err != nil {
-				// User not found in either active or pending
-				return nil, RequestError{
-					StatusCode: http.StatusNotFound,
-					Err:        fmt.Errorf("user with email %s not found in active teammates or pending invitations. Original active error: %v. Pending error: %v", email, requestErr.Err, pendingErr.Err),
-				}
-			}
-			return pendingUser, RequestError{StatusCode: http.StatusOK, Err: nil}
+	if requestErr.Err == nil {
+		var user *User
+		if user, requestErr = c.ReadUserByUsername(ctx, username); requestErr.StatusCode != http.StatusNotFound {
+			return user, requestErr
 		}
-		return nil, requestErr
+
+		// The teammate was removed since it was listed, it may have been invited again.
+		c.Usernames.forget(c.OnBehalfOf, email)
 	}
 
-	respBody, statusCode, err := c.Get(ctx, "GET", "/teammates/"+username)
-	if err != nil {
-		return nil, RequestError{
-			StatusCode: statusCode,
-			Err:        err,
-		}
+	// If user not found in active teammates, check pending invitations
+	if requestErr.StatusCode != http.StatusNotFound {
+		return nil, requestErr
 	}
 
-	var u User
-	err = json.Unmarshal([]byte(respBody), &u)
-	if err != nil {
+	pendingUser, pendingErr := c.ReadPendingUser(ctx, email)
+	if pendingErr.Err != nil {
+		// User not found in either active or pending
 		return nil, RequestError{
-			StatusCode: http.StatusInternalServerError,
-			Err:        err,
+			StatusCode: http.StatusNotFound,
+			Err:        fmt.Errorf("user with email %s not found in active teammates or pending invitations. Original active error: %v. Pending error: %v", email, requestErr.Err, pendingErr.Err),
 		}
 	}
-	return &u, RequestError{StatusCode: http.StatusOK, Err: nil}
+
+	return pendingUser, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
-func (c *Client) UpdateUser(ctx context.Context, email string, scopes []string, isAdmin bool) (*User, RequestError) {
+func (c *Client) UpdateUser(
+	ctx context.Context,
+	email string,
+	scopes []string,
+	isAdmin bool,
+	access *TeammateSubuserAccess,
+) (*User, RequestError) {
 	username, requestErr := c.GetUsernameByEmail(ctx, email)
 	if requestErr.Err != nil {
 		return nil, requestErr
 	}
 
-	respBody, statusCode, err := c.Post(ctx, "PATCH", "/teammates/"+username, User{
+	respBody, statusCode, err := c.Post(ctx, "PATCH", "/teammates/"+username, newTeammateRequest(User{
 		IsAdmin: isAdmin,
 		Scopes:  scopes,
-	})
+	}, access))
 	if err != nil {
 		return nil, RequestError{
 			StatusCode: statusCode,
@@ -180,7 +394,13 @@ func (c *Client) UpdateUser(ctx context.Context, email string, scopes []string,
 	return parseUser(respBody)
 }
 
-func (c *Client) UpdateSSOUser(ctx context.Context, firstName, lastName, email string, scopes []string, isAdmin bool) (*User, RequestError) {
+func (c *Client) UpdateSSOUser(
+	ctx context.Context,
+	firstName, lastName, email string,
+	scopes []string,
+	isAdmin bool,
+	access *TeammateSubuserAccess,
+) (*User, RequestError) {
 	username, requestErr := c.GetUsernameByEmail(ctx, email)
 	if requestErr.Err != nil {
 		// If user not found in active teammates, they might be pending
@@ -194,12 +414,12 @@ func (c *Client) UpdateSSOUser(ctx context.Context, firstName, lastName, email s
 		return nil, requestErr
 	}
 
-	respBody, statusCode, err := c.Post(ctx, "PATCH", "/sso/teammates/"+username, User{
+	respBody, statusCode, err := c.Post(ctx, "PATCH", "/sso/teammates/"+username, newTeammateRequest(User{
 		FirstName: firstName,
 		LastName:  lastName,
 		IsAdmin:   isAdmin,
 		Scopes:    scopes,
-	})
+	}, access))
 	if err != nil {
 		return nil, RequestError{
 			StatusCode: statusCode,
@@ -234,6 +454,8 @@ func (c *Client) DeleteUser(ctx context.Context, email string) (bool, RequestErr
 		}
 	}
 
+	c.Usernames.forget(c.OnBehalfOf, email)
+
 	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
@@ -304,9 +526,10 @@ func (c *Client) ReadPendingUser(ctx context.Context, email string) (*User, Requ
 		if pendingUser.Email == email {
 			// Convert pending user to User struct
 			user := &User{
-				Email:   pendingUser.Email,
-				IsAdmin: pendingUser.IsAdmin,
-				Scopes:  pendingUser.Scopes,
+				Email:          pendingUser.Email,
+				IsAdmin:        pendingUser.IsAdmin,
+				Scopes:         pendingUser.Scopes,
+				ExpirationDate: pendingUser.ExpirationDate,
 				// Mark as pending by setting a special user type
 				UserType: "pending",
 			}
@@ -319,3 +542,20 @@ func (c *Client) ReadPendingUser(ctx context.Context, email string) (*User, Requ
 		Err:        fmt.Errorf("pending user with email %s not found. Available pending users: %v. This may mean the user has already accepted the invitation or the invitation has expired", email, pendingDetails),
 	}
 }
+
+// ResendPendingUser resends the invitation of a pending user, which restarts its expiration.
+func (c *Client) ResendPendingUser(ctx context.Context, email string) (bool, RequestError) {
+	token, requestErr := c.GetPendingUserToken(ctx, email)
+	if requestErr.Err != nil {
+		return false, requestErr
+	}
+
+	if _, statusCode, err := c.Post(ctx, "POST", "/teammates/pending/"+token+"/resend", nil); err != nil {
+		return false, RequestError{
+			StatusCode: statusCode,
+			Err:        fmt.Errorf("failed resending the invitation: %w", err),
+		}
+	}
+
+	return true, RequestError{StatusCode: http.StatusOK, Err: nil}
+}
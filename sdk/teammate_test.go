@@ -0,0 +1,215 @@
+package sendgrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateSSOUserSubuserAccess(t *testing.T) {
+	var bodies []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		bodies = append(bodies, body)
+
+		fmt.Fprint(w, `{"email": "support@example.com"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "")
+	ctx := context.Background()
+
+	access := &TeammateSubuserAccess{Subusers: []SubuserAccess{
+		{ID: 42, PermissionType: "restricted", Scopes: []string{"mail.send"}},
+	}}
+
+	for _, access := range []*TeammateSubuserAccess{access, {}, nil} {
+		if _, requestErr := client.CreateSSOUser(ctx, "Ada", "Lovelace", "support@example.com", nil, false, access); requestErr.Err != nil {
+			t.Fatal(requestErr.Err)
+		}
+	}
+
+	restricted := bodies[0]
+	if restricted["has_restricted_subuser_access"] != true {
+		t.Errorf("has_restricted_subuser_access = %v, want true", restricted["has_restricted_subuser_access"])
+	}
+
+	subuserAccess, _ := restricted["subuser_access"].([]interface{})
+	if len(subuserAccess) != 1 || subuserAccess[0].(map[string]interface{})["id"] != float64(42) {
+		t.Errorf("subuser_access = %v, want the access to subuser 42", restricted["subuser_access"])
+	}
+
+	// Without subusers, the restriction is lifted.
+	if lifted := bodies[1]; lifted["has_restricted_subuser_access"] != false {
+		t.Errorf("has_restricted_subuser_access = %v, want false", lifted["has_restricted_subuser_access"])
+	}
+
+	// Without access, the access is left unchanged.
+	if _, ok := bodies[2]["has_restricted_subuser_access"]; ok {
+		t.Errorf("has_restricted_subuser_access sent without access: %v", bodies[2])
+	}
+}
+
+func TestResendPendingUser(t *testing.T) {
+	var resent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/teammates/pending":
+			fmt.Fprint(w, `{"result": [{"email": "new@example.com", "token": "token-1", "expiration_date": 1700000000}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/teammates/pending/token-1/resend":
+			resent = "token-1"
+
+			fmt.Fprint(w, `{"token": "token-1", "email": "new@example.com"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "")
+	ctx := context.Background()
+
+	user, requestErr := client.ReadPendingUser(ctx, "new@example.com")
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if user.ExpirationDate != 1700000000 {
+		t.Errorf("ExpirationDate = %d, want %d", user.ExpirationDate, 1700000000)
+	}
+
+	if _, requestErr := client.ResendPendingUser(ctx, "new@example.com"); requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if resent != "token-1" {
+		t.Error("the invitation wasn't resent")
+	}
+}
+
+func TestReadUsers(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/teammates":
+			fmt.Fprint(w, `{"result": [{"username": "ada", "email": "ada@example.com", "is_admin": true}]}`)
+		case "/teammates/pending":
+			fmt.Fprint(w, `{"result": [{"email": "grace@example.com", "token": "abc", "expiration_date": 1704067200, "scopes": ["mail.send"]}]}`)
+		case "/teammates/ada":
+			fmt.Fprint(w, `{"username": "ada", "email": "ada@example.com", "scopes": ["mail.send"]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "")
+	ctx := context.Background()
+
+	users, requestErr := client.ReadUsers(ctx)
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if len(users) != 1 || users[0].Username != "ada" || !users[0].IsAdmin {
+		t.Errorf("users = %+v, want ada as an admin", users)
+	}
+
+	pending, requestErr := client.ReadPendingUsers(ctx)
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if len(pending) != 1 || pending[0].UserType != "pending" || pending[0].ExpirationDate != 1704067200 {
+		t.Errorf("pending users = %+v, want the invitation of grace", pending)
+	}
+
+	user, requestErr := client.ReadUserByUsername(ctx, "ada")
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if len(user.Scopes) != 1 || user.Scopes[0] != "mail.send" {
+		t.Errorf("scopes = %v, want [mail.send]", user.Scopes)
+	}
+
+	if want := []string{"/teammates", "/teammates/pending", "/teammates/ada"}; fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("requested %v, want %v", paths, want)
+	}
+}
+
+func TestReadUserUsernameCache(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/teammates":
+			fmt.Fprint(w, `{"result": [{"username": "ada", "email": "ada@example.com"}, {"username": "alan", "email": "alan@example.com"}]}`)
+		case "/teammates/pending":
+			fmt.Fprint(w, `{"result": [{"email": "grace@example.com", "token": "abc"}]}`)
+		case "/teammates/ada":
+			fmt.Fprint(w, `{"username": "ada", "email": "ada@example.com"}`)
+		case "/teammates/alan":
+			fmt.Fprint(w, `{"username": "alan", "email": "alan@example.com"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cache := NewUsernameCache()
+	ctx := context.Background()
+
+	// The clients of a provider run share the cache, the teammates are listed once.
+	for _, email := range []string{"ada@example.com", "alan@example.com"} {
+		client := NewClient("test-api-key", server.URL, "")
+		client.Usernames = cache
+
+		if user, requestErr := client.ReadUser(ctx, email); requestErr.Err != nil || user.Email != email {
+			t.Fatalf("ReadUser(%s) = %+v, %v", email, user, requestErr.Err)
+		}
+	}
+
+	want := []string{"GET /teammates", "GET /teammates/ada", "GET /teammates/alan"}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("requested %v, want %v", paths, want)
+	}
+
+	client := NewClient("test-api-key", server.URL, "")
+	client.Usernames = NewUsernameCache()
+	paths = nil
+
+	// The known username is read directly, without listing the teammates.
+	if user, requestErr := client.ReadUserWithUsername(ctx, "ada@example.com", "ada"); requestErr.Err != nil || user.Username != "ada" {
+		t.Fatalf("ReadUserWithUsername = %+v, %v", user, requestErr.Err)
+	}
+
+	if want := []string{"GET /teammates/ada"}; fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("requested %v, want %v", paths, want)
+	}
+
+	// A stale username falls back to the listing, then to the pending invitations.
+	user, requestErr := client.ReadUserWithUsername(ctx, "grace@example.com", "grace")
+	if requestErr.Err != nil || user.UserType != "pending" {
+		t.Fatalf("ReadUserWithUsername = %+v, %v, want the pending invitation", user, requestErr.Err)
+	}
+
+	if _, requestErr := client.ReadUserWithUsername(ctx, "linus@example.com", ""); requestErr.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d for an unknown teammate", requestErr.StatusCode, http.StatusNotFound)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 // Template is a Sendgrid transactional template.
@@ -18,7 +19,8 @@ type Template struct {
 }
 
 type Templates struct {
-	Result []Template `json:"result"`
+	Result   []Template   `json:"result"`
+	Metadata pageMetadata `json:"_metadata"` //nolint:tagliatelle
 }
 
 func parseTemplate(respBody string) (*Template, RequestError) {
@@ -35,7 +37,7 @@ func parseTemplate(respBody string) (*Template, RequestError) {
 	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
-func parseTemplates(respBody string) ([]Template, RequestError) {
+func parseTemplates(respBody string) (*Templates, RequestError) {
 	var body Templates
 
 	err := json.Unmarshal([]byte(respBody), &body)
@@ -46,7 +48,7 @@ func parseTemplates(respBody string) ([]Template, RequestError) {
 		}
 	}
 
-	return body.Result, RequestError{StatusCode: http.StatusOK, Err: nil}
+	return &body, RequestError{StatusCode: http.StatusOK, Err: nil}
 }
 
 // CreateTemplate creates a transactional template and returns it.
@@ -96,16 +98,40 @@ func (c *Client) ReadTemplate(ctx context.Context, id string) (*Template, Reques
 	return parseTemplate(respBody)
 }
 
+// ReadTemplates retrieves all the transactional templates of the given generations, following the pagination.
+// generation is "legacy", "dynamic" or both separated by a comma.
 func (c *Client) ReadTemplates(ctx context.Context, generation string) ([]Template, RequestError) {
-	respBody, statusCode, err := c.Get(ctx, "GET", "/templates?page_size=200&generations="+generation)
-	if err != nil {
-		return nil, RequestError{
-			StatusCode: statusCode,
-			Err:        fmt.Errorf("failed reading template: %w", err),
+	templates := []Template{}
+	pageToken := ""
+
+	for {
+		path := "/templates?page_size=200&generations=" + url.QueryEscape(generation)
+		if pageToken != "" {
+			path += "&page_token=" + url.QueryEscape(pageToken)
 		}
-	}
 
-	return parseTemplates(respBody)
+		respBody, statusCode, err := c.Get(ctx, "GET", path)
+		if err != nil {
+			return nil, RequestError{
+				StatusCode: statusCode,
+				Err:        fmt.Errorf("failed reading template: %w", err),
+			}
+		}
+
+		page, requestErr := parseTemplates(respBody)
+		if requestErr.Err != nil {
+			return nil, requestErr
+		}
+
+		templates = append(templates, page.Result...)
+
+		next := nextPageToken(page.Metadata.Next)
+		if next == "" || next == pageToken || len(page.Result) == 0 {
+			return templates, RequestError{StatusCode: http.StatusOK, Err: nil}
+		}
+
+		pageToken = next
+	}
 }
 
 // UpdateTemplate edits a transactional template and returns it.
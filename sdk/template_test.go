@@ -0,0 +1,44 @@
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadTemplatesPagination(t *testing.T) {
+	pages := map[string]string{
+		"": `{"result":[{"id":"d-1","name":"one"},{"id":"d-2","name":"two"}],` +
+			`"_metadata":{"next":"https://api.sendgrid.com/v3/templates?page_size=200&page_token=abc"}}`,
+		"abc": `{"result":[{"id":"d-3","name":"three","versions":[{"id":"v-1","active":1}]}],"_metadata":{}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("generations"); got != "legacy,dynamic" {
+			t.Errorf("generations = %q, want %q", got, "legacy,dynamic")
+		}
+
+		page, ok := pages[r.URL.Query().Get("page_token")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", server.URL, "")
+
+	templates, requestErr := client.ReadTemplates(context.Background(), "legacy,dynamic")
+	if requestErr.Err != nil {
+		t.Fatal(requestErr.Err)
+	}
+
+	if len(templates) != 3 || templates[2].ID != "d-3" || templates[2].Versions[0].Active != 1 {
+		t.Errorf("ReadTemplates() = %+v, want the templates of both pages", templates)
+	}
+}
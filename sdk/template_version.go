@@ -16,9 +16,9 @@ type TemplateVersion struct {
 	Warnings             []Warning `json:"warning,omitempty"`
 	Active               int       `json:"active,omitempty"`
 	Name                 string    `json:"name,omitempty"`
-	HTMLContent          string    `json:"html_content,omitempty"`           //nolint:tagliatelle
-	PlainContent         string    `json:"plain_content,omitempty"`          //nolint:tagliatelle
-	GeneratePlainContent bool      `json:"generate_plain_content,omitempty"` //nolint:tagliatelle
+	HTMLContent          string    `json:"html_content,omitempty"`  //nolint:tagliatelle
+	PlainContent         string    `json:"plain_content,omitempty"` //nolint:tagliatelle
+	GeneratePlainContent bool      `json:"generate_plain_content"`  //nolint:tagliatelle
 	Subject              string    `json:"subject,omitempty"`
 	Editor               string    `json:"editor,omitempty"`
 	TestData             string    `json:"test_data,omitempty"` //nolint:tagliatelle
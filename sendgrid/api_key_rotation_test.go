@@ -0,0 +1,171 @@
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+)
+
+func TestAPIKeyRotationPlan(t *testing.T) {
+	rotatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name               string
+		rotation           apiKeyRotation
+		now                time.Time
+		wantRotate         bool
+		wantDeletePrevious bool
+	}{
+		{
+			name:     "no rotation",
+			rotation: apiKeyRotation{rotatedAt: rotatedAt},
+			now:      rotatedAt.Add(365 * day),
+		},
+		{
+			name:     "not due yet",
+			rotation: apiKeyRotation{enabled: true, rotateAfter: 30 * day, rotatedAt: rotatedAt},
+			now:      rotatedAt.Add(29 * day),
+		},
+		{
+			name:       "due",
+			rotation:   apiKeyRotation{enabled: true, rotateAfter: 30 * day, rotatedAt: rotatedAt},
+			now:        rotatedAt.Add(30 * day),
+			wantRotate: true,
+		},
+		{
+			name:       "keepers changed",
+			rotation:   apiKeyRotation{enabled: true, keepersChanged: true, rotatedAt: rotatedAt},
+			now:        rotatedAt,
+			wantRotate: true,
+		},
+		{
+			name:     "unknown age",
+			rotation: apiKeyRotation{enabled: true, rotateAfter: time.Hour},
+			now:      rotatedAt,
+		},
+		{
+			name:     "predecessor in its grace period",
+			rotation: apiKeyRotation{enabled: true, gracePeriod: day, rotatedAt: rotatedAt, hasPrevious: true},
+			now:      rotatedAt.Add(time.Hour),
+		},
+		{
+			name:               "predecessor after its grace period",
+			rotation:           apiKeyRotation{enabled: true, gracePeriod: day, rotatedAt: rotatedAt, hasPrevious: true},
+			now:                rotatedAt.Add(day),
+			wantDeletePrevious: true,
+		},
+		{
+			name:               "predecessor deleted at the next apply by default",
+			rotation:           apiKeyRotation{enabled: true, rotatedAt: rotatedAt, hasPrevious: true},
+			now:                rotatedAt.Add(time.Minute),
+			wantDeletePrevious: true,
+		},
+		{
+			name:               "predecessor of a removed rotation",
+			rotation:           apiKeyRotation{rotatedAt: rotatedAt, hasPrevious: true},
+			now:                rotatedAt,
+			wantDeletePrevious: true,
+		},
+		{
+			name: "predecessor replaced by a new rotation",
+			rotation: apiKeyRotation{
+				enabled: true, keepersChanged: true, gracePeriod: day, rotatedAt: rotatedAt, hasPrevious: true,
+			},
+			now:        rotatedAt.Add(2 * day),
+			wantRotate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The expiry is detected by the refresh, the plan follows it.
+			rotation := tt.rotation
+			rotation.rotationDue, rotation.previousExpired = rotation.expiry(tt.now)
+
+			rotate, deletePrevious := rotation.plan()
+			if rotate != tt.wantRotate || deletePrevious != tt.wantDeletePrevious {
+				t.Errorf("plan() = %v, %v, want %v, %v", rotate, deletePrevious, tt.wantRotate, tt.wantDeletePrevious)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRotationExpiryOnRefresh(t *testing.T) {
+	rotatedAt := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+
+	d := resourceSendgridAPIKey().TestResourceData()
+	//nolint:errcheck
+	d.Set("rotation", []interface{}{map[string]interface{}{"rotate_after": "24h", "grace_period": "72h"}})
+	//nolint:errcheck
+	d.Set("rotated_at", rotatedAt)
+	//nolint:errcheck
+	d.Set("previous_api_key_id", "previous")
+
+	// Until the state is refreshed, the elapsed time doesn't plan anything.
+	if rotate, deletePrevious := newAPIKeyRotation(d.Get).plan(); rotate || deletePrevious {
+		t.Errorf("plan() = %v, %v before the refresh, want nothing", rotate, deletePrevious)
+	}
+
+	newAPIKeyRotation(d.Get).setExpiry(d, time.Now())
+
+	if !d.Get("rotation_due").(bool) || d.Get("previous_api_key_expired").(bool) {
+		t.Errorf("rotation_due = %v, previous_api_key_expired = %v, want the rotation only",
+			d.Get("rotation_due"), d.Get("previous_api_key_expired"))
+	}
+
+	if rotate, _ := newAPIKeyRotation(d.Get).plan(); !rotate {
+		t.Error("expected the rotation detected by the refresh to be planned")
+	}
+}
+
+func TestWriteAPIKeySink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command of the test needs a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secrets", "api_key")
+	commandOutput := filepath.Join(dir, "command_output")
+
+	apiKey := &sendgrid.APIKey{ID: "key-id", Name: "my-key", APIKey: "SG.secret"}
+	sink := map[string]interface{}{
+		"file":    file,
+		"command": `printf '%s:%s:%s' "$SENDGRID_API_KEY_ID" "$SENDGRID_API_KEY_NAME" "$(cat)" > ` + commandOutput,
+	}
+
+	if err := writeAPIKeySink(context.Background(), sink, apiKey); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the file to be readable by its owner only, got %v", info.Mode().Perm())
+	}
+
+	for path, want := range map[string]string{file: "SG.secret", commandOutput: "key-id:my-key:SG.secret"} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(content) != want {
+			t.Errorf("%s: expected %q, got %q", path, want, content)
+		}
+	}
+
+	sink = map[string]interface{}{"file": "", "command": "echo failure >&2; exit 3"}
+	if err := writeAPIKeySink(context.Background(), sink, apiKey); !errors.Is(err, ErrWriteAPIKeySink) {
+		t.Errorf("expected ErrWriteAPIKeySink, got %v", err)
+	}
+}
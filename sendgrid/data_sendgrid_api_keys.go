@@ -0,0 +1,142 @@
+package sendgrid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSendgridAPIKeys() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the API keys of the account or of a subuser, with their scopes, " +
+			"including the keys created outside Terraform. SendGrid doesn't report when a key was last used.",
+		ReadContext: dataSendgridAPIKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"sub_user_on_behalf_of": {
+				Type:        schema.TypeString,
+				Description: "The subuser's username. The keys of the subuser are listed instead of the ones of the account.",
+				Optional:    true,
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Description: "Only list the keys whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Description: "Only list the keys having this scope, e.g. mail.send.",
+				Optional:    true,
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Description: "The IDs of the keys matching the filters.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"api_keys": {
+				Type:        schema.TypeList,
+				Description: "The keys matching the filters, sorted by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the key.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the key.",
+							Computed:    true,
+						},
+						"scopes": {
+							Type:        schema.TypeList,
+							Description: "The scopes of the key, sorted.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSendgridAPIKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	onBehalfOf := d.Get("sub_user_on_behalf_of").(string)
+	c := config.NewClient(onBehalfOf)
+
+	namePrefix := d.Get("name_prefix").(string)
+	scope := d.Get("scope").(string)
+
+	apiKeysStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadAPIKeys(ctx)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	all := apiKeysStruct.([]sendgrid.APIKey)
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Name != all[j].Name {
+			return all[i].Name < all[j].Name
+		}
+
+		return all[i].ID < all[j].ID
+	})
+
+	ids := []string{}
+	apiKeys := []interface{}{}
+
+	for _, listed := range all {
+		if !strings.HasPrefix(listed.Name, namePrefix) {
+			continue
+		}
+
+		// The list doesn't give the scopes of the keys, each key is read for them.
+		apiKeyStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.ReadAPIKey(ctx, listed.ID)
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed reading the scopes of API key %q: %w", listed.ID, err))
+		}
+
+		scopes := append([]string{}, apiKeyStruct.(*sendgrid.APIKey).Scopes...)
+		sort.Strings(scopes)
+
+		if scope != "" && !scopeInScopes(scopes, scope) {
+			continue
+		}
+
+		ids = append(ids, listed.ID)
+		apiKeys = append(apiKeys, map[string]interface{}{
+			"id":     listed.ID,
+			"name":   listed.Name,
+			"scopes": scopes,
+		})
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s", onBehalfOf, namePrefix, scope)))
+	d.SetId(hex.EncodeToString(hash[:]))
+
+	//nolint:errcheck
+	d.Set("ids", ids)
+	//nolint:errcheck
+	d.Set("api_keys", apiKeys)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSendgridPrebuiltDesign() *schema.Resource {
+	s := resourceSendgridDesign().Schema
+
+	for _, val := range s {
+		val.Computed = true
+		val.Optional = false
+		val.Required = false
+		val.Default = nil
+		val.ValidateFunc = nil
+		val.ForceNew = false
+	}
+
+	delete(s, "generate_plain_content")
+
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The ID of the pre-built design to retrieve.",
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The name of the pre-built design to retrieve.",
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves a pre-built design of SendGrid, " +
+			"to start a `sendgrid_design` or a template version from its content.",
+		ReadContext: dataSendgridPrebuiltDesignRead,
+		Schema:      s,
+	}
+}
+
+func dataSendgridPrebuiltDesignRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	id := d.Get("id").(string)
+
+	if name := d.Get("name").(string); name != "" {
+		designsStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.ReadPrebuiltDesigns(ctx)
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, design := range designsStruct.([]sendgrid.Design) {
+			if design.Name == name {
+				id = design.ID
+
+				break
+			}
+		}
+
+		if id == "" {
+			return diag.FromErr(fmt.Errorf("%w with name %q among the pre-built designs", ErrDesignNotFound, name))
+		}
+	}
+
+	designStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadPrebuiltDesign(ctx, id)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	parseDesign(d, designStruct.(*sendgrid.Design))
+
+	return nil
+}
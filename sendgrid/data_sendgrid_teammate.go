@@ -53,6 +53,53 @@ func dataSendgridTeammate() *schema.Resource {
 				Optional:    true,
 				Description: "True if teammate has admin privileges",
 			},
+			"has_restricted_subuser_access": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if teammate is restricted to the subusers of subuser_access",
+			},
+			"subuser_access": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Effective access of the teammate to each subuser it's restricted to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subuser_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Subuser's ID",
+						},
+						"subuser": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subuser's username",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subuser's email",
+						},
+						"disabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "True if the subuser is disabled",
+						},
+						"permission_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Teammate's access to the subuser: admin, or restricted to scopes",
+						},
+						"scopes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Scopes of the teammate on the subuser",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -81,7 +128,26 @@ func dataSendgridTeammateRead(context context.Context, d *schema.ResourceData, m
 		d.Set("first_name", teammate.FirstName),
 		d.Set("scopes", teammate.Scopes),
 		d.Set("is_admin", teammate.IsAdmin),
+		d.Set("has_restricted_subuser_access", teammate.HasRestrictedSubuserAccess),
+		d.Set("subuser_access", flattenTeammateSubuserAccessDetails(teammate.SubuserAccess)),
 	)
 
 	return diag.FromErr(retErr.ErrorOrNil())
 }
+
+func flattenTeammateSubuserAccessDetails(accesses []sendgrid.SubuserAccess) []interface{} {
+	flattened := make([]interface{}, 0, len(accesses))
+
+	for _, access := range accesses {
+		flattened = append(flattened, map[string]interface{}{
+			"subuser_id":      access.ID,
+			"subuser":         access.Username,
+			"email":           access.Email,
+			"disabled":        access.Disabled,
+			"permission_type": access.PermissionType,
+			"scopes":          access.Scopes,
+		})
+	}
+
+	return flattened
+}
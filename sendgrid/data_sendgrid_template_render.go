@@ -0,0 +1,168 @@
+package sendgrid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/handlebars"
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSendgridTemplateRender() *schema.Resource { //nolint:funlen
+	return &schema.Resource{
+		Description: "Renders the subject, the HTML and the plain content of a template version locally, " +
+			"with the Handlebars helpers supported by SendGrid. " +
+			"Nothing is sent: the rendered output can be compared with snapshots in CI " +
+			"before a version is activated.",
+		ReadContext: dataSendgridTemplateRenderRead,
+
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the transactional template to render. Conflicts with the content arguments.",
+				Optional:     true,
+				ExactlyOneOf: []string{"template_id", "html_content"},
+			},
+			"version_id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the version to render, the active version of the template by default.",
+				Optional:     true,
+				RequiredWith: []string{"template_id"},
+			},
+			"subject": {
+				Type:          schema.TypeString,
+				Description:   "Subject to render without reading a template version.",
+				Optional:      true,
+				ConflictsWith: []string{"template_id"},
+			},
+			"html_content": {
+				Type:          schema.TypeString,
+				Description:   "HTML content to render without reading a template version.",
+				Optional:      true,
+				ConflictsWith: []string{"template_id"},
+				ExactlyOneOf:  []string{"template_id", "html_content"},
+			},
+			"plain_content": {
+				Type:          schema.TypeString,
+				Description:   "Plain content to render without reading a template version.",
+				Optional:      true,
+				ConflictsWith: []string{"template_id"},
+			},
+			"test_data": {
+				Type: schema.TypeString,
+				Description: "JSON data the template is rendered with. " +
+					"Defaults to the `test_data` of the template version.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"rendered_subject": {
+				Type:        schema.TypeString,
+				Description: "The rendered subject.",
+				Computed:    true,
+			},
+			"rendered_html": {
+				Type:        schema.TypeString,
+				Description: "The rendered HTML content.",
+				Computed:    true,
+			},
+			"rendered_plain_content": {
+				Type:        schema.TypeString,
+				Description: "The rendered plain content.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSendgridTemplateRenderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	version := &sendgrid.TemplateVersion{
+		Subject:      d.Get("subject").(string),
+		HTMLContent:  d.Get("html_content").(string),
+		PlainContent: d.Get("plain_content").(string),
+	}
+
+	if templateID := d.Get("template_id").(string); templateID != "" {
+		var err error
+		if version, err = readTemplateVersionToRender(ctx, d, m, templateID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	testData := version.TestData
+	if v, ok := d.GetOk("test_data"); ok {
+		testData = v.(string)
+	}
+
+	data, err := handlebars.ParseData(testData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash := sha256.New()
+
+	for _, content := range []struct {
+		key  string
+		name string
+		src  string
+	}{
+		{key: "rendered_subject", name: "subject", src: version.Subject},
+		{key: "rendered_html", name: "html_content", src: version.HTMLContent},
+		{key: "rendered_plain_content", name: "plain_content", src: version.PlainContent},
+	} {
+		rendered, err := handlebars.Render(content.src, data)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("could not render %s:\n%w", content.name, err))
+		}
+
+		//nolint:errcheck
+		d.Set(content.key, rendered)
+		hash.Write([]byte(rendered))
+	}
+
+	d.SetId(hex.EncodeToString(hash.Sum(nil)))
+
+	return nil
+}
+
+// readTemplateVersionToRender reads the version given by version_id, or the active version of the template.
+func readTemplateVersionToRender(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+	templateID string,
+) (*sendgrid.TemplateVersion, error) {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	if versionID := d.Get("version_id").(string); versionID != "" {
+		templateVersionStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.ReadTemplateVersion(ctx, templateID, versionID)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return templateVersionStruct.(*sendgrid.TemplateVersion), nil
+	}
+
+	templateStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadTemplate(ctx, templateID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	template := templateStruct.(*sendgrid.Template)
+	for i := range template.Versions {
+		if template.Versions[i].Active == 1 {
+			return &template.Versions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrTemplateNoActiveVersion, templateID)
+}
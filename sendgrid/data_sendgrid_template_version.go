@@ -18,6 +18,8 @@ func dataSendgridTemplateVersion() *schema.Resource {
 			val.Required = false
 			val.Default = nil
 			val.ValidateFunc = nil
+			val.ConflictsWith = nil
+			val.DiffSuppressFunc = nil
 		}
 	}
 
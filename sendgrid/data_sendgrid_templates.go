@@ -0,0 +1,247 @@
+package sendgrid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// templateUpdatedAtLayout is the layout of the dates of the templates API, in UTC.
+const templateUpdatedAtLayout = "2006-01-02 15:04:05"
+
+func dataSendgridTemplates() *schema.Resource { //nolint:funlen
+	return &schema.Resource{
+		Description: "Lists the transactional templates, following the pagination, " +
+			"with their active version. Useful to audit stale templates or to build `for_each` maps.",
+		ReadContext: dataSendgridTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"generation": {
+				Type:         schema.TypeString,
+				Description:  "Only list the templates of this generation, legacy or dynamic. Both are listed by default.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"legacy", "dynamic"}, false),
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "Only list the templates whose name matches this regular expression.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"updated_after": {
+				Type: schema.TypeString,
+				Description: "Only list the templates updated after this RFC 3339 date, e.g. 2024-01-02T15:04:05Z. " +
+					"Use `timeadd(plantimestamp(), \"-8760h\")` and compare to the full list to find stale templates.",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Description: "The IDs of the templates matching the filters.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"templates": {
+				Type:        schema.TypeList,
+				Description: "The templates matching the filters, sorted by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the template.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the template.",
+							Computed:    true,
+						},
+						"generation": {
+							Type:        schema.TypeString,
+							Description: "The generation of the template, legacy or dynamic.",
+							Computed:    true,
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Description: "The date and time of the last update of the template, in UTC.",
+							Computed:    true,
+						},
+						"version_count": {
+							Type:        schema.TypeInt,
+							Description: "The number of versions of the template.",
+							Computed:    true,
+						},
+						"active_version_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the active version, empty when no version is active.",
+							Computed:    true,
+						},
+						"active_version_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the active version.",
+							Computed:    true,
+						},
+						"active_version_updated_at": {
+							Type:        schema.TypeString,
+							Description: "The date and time of the last update of the active version, in UTC.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSendgridTemplatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	generation := d.Get("generation").(string)
+	nameRegex := d.Get("name_regex").(string)
+	updatedAfter := d.Get("updated_after").(string)
+
+	filter, err := newTemplatesFilter(nameRegex, updatedAfter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	generations := generation
+	if generations == "" {
+		generations = "legacy,dynamic"
+	}
+
+	templatesStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadTemplates(ctx, generations)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	all := templatesStruct.([]sendgrid.Template)
+
+	// Sorted, so that the list doesn't change with the order of the pages of SendGrid.
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Name != all[j].Name {
+			return all[i].Name < all[j].Name
+		}
+
+		return all[i].ID < all[j].ID
+	})
+
+	ids := []string{}
+	templates := []interface{}{}
+
+	for _, template := range all {
+		if !filter.match(template) {
+			continue
+		}
+
+		ids = append(ids, template.ID)
+		templates = append(templates, flattenTemplateSummary(template))
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s", generation, nameRegex, updatedAfter)))
+	d.SetId(hex.EncodeToString(hash[:]))
+
+	//nolint:errcheck
+	d.Set("ids", ids)
+	//nolint:errcheck
+	d.Set("templates", templates)
+
+	return nil
+}
+
+// templatesFilter selects the templates listed by the sendgrid_templates data source.
+type templatesFilter struct {
+	name         *regexp.Regexp
+	updatedAfter time.Time
+}
+
+func newTemplatesFilter(nameRegex, updatedAfter string) (templatesFilter, error) {
+	var filter templatesFilter
+
+	if nameRegex != "" {
+		re, err := regexp.Compile(nameRegex)
+		if err != nil {
+			return filter, fmt.Errorf("invalid name_regex: %w", err)
+		}
+
+		filter.name = re
+	}
+
+	if updatedAfter != "" {
+		t, err := time.Parse(time.RFC3339, updatedAfter)
+		if err != nil {
+			return filter, fmt.Errorf("invalid updated_after: %w", err)
+		}
+
+		filter.updatedAfter = t
+	}
+
+	return filter, nil
+}
+
+func (f templatesFilter) match(template sendgrid.Template) bool {
+	if f.name != nil && !f.name.MatchString(template.Name) {
+		return false
+	}
+
+	if f.updatedAfter.IsZero() {
+		return true
+	}
+
+	// A template whose date can't be parsed is kept rather than silently hidden from an audit.
+	updatedAt, ok := parseTemplateUpdatedAt(template.UpdatedAt)
+
+	return !ok || updatedAt.After(f.updatedAfter)
+}
+
+// parseTemplateUpdatedAt parses the dates of the templates API, which are in UTC without a time zone,
+// while accepting RFC 3339 dates too.
+func parseTemplateUpdatedAt(s string) (time.Time, bool) {
+	for _, layout := range []string{templateUpdatedAtLayout, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func flattenTemplateSummary(template sendgrid.Template) map[string]interface{} {
+	summary := map[string]interface{}{
+		"id":                        template.ID,
+		"name":                      template.Name,
+		"generation":                template.Generation,
+		"updated_at":                template.UpdatedAt,
+		"version_count":             len(template.Versions),
+		"active_version_id":         "",
+		"active_version_name":       "",
+		"active_version_updated_at": "",
+	}
+
+	for _, version := range template.Versions {
+		if version.Active == 1 {
+			summary["active_version_id"] = version.ID
+			summary["active_version_name"] = version.Name
+			summary["active_version_updated_at"] = version.UpdatedAt
+
+			break
+		}
+	}
+
+	return summary
+}
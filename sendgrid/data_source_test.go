@@ -88,6 +88,74 @@ func TestAccDataSourceSendgridTemplateVersion(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceSendgridTemplateRender(t *testing.T) {
+	templateName := "terraform-template-render-data-" + acctest.RandString(10)
+	versionName := "terraform-version-render-data-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSendgridTemplateRenderConfig(templateName, versionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_template_render.test", "rendered_subject", "Hello Ada"),
+					resource.TestCheckResourceAttr(
+						"data.sendgrid_template_render.test", "rendered_html", "<html><body>Welcome Ada</body></html>"),
+					resource.TestCheckResourceAttr(
+						"data.sendgrid_template_render.override", "rendered_subject", "Hello Grace"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSendgridTemplates(t *testing.T) {
+	prefix := "terraform-templates-data-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSendgridTemplatesConfig(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_templates.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.sendgrid_templates.test", "templates.#", "2"),
+					resource.TestCheckResourceAttr("data.sendgrid_templates.test", "templates.0.version_count", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.sendgrid_templates.test", "templates.0.active_version_id",
+						"sendgrid_template_version.test.0", "id"),
+					resource.TestCheckResourceAttr("data.sendgrid_templates.legacy", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSendgridAPIKeys(t *testing.T) {
+	prefix := "terraform-api-keys-data-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSendgridAPIKeysConfig(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_api_keys.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.sendgrid_api_keys.test", "api_keys.0.name", prefix+"-0"),
+					resource.TestCheckTypeSetElemAttr("data.sendgrid_api_keys.test", "api_keys.0.scopes.*", "mail.send"),
+					resource.TestCheckResourceAttr("data.sendgrid_api_keys.templates", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.sendgrid_api_keys.templates", "ids.0",
+						"sendgrid_api_key.test.1", "id"),
+				),
+			},
+		},
+	})
+}
+
 // Config functions
 func testAccDataSourceSendgridTeammateConfig(email string, scopes []string) string {
 	return fmt.Sprintf(`
@@ -156,3 +224,85 @@ data "sendgrid_template_version" "test" {
 }
 `, templateName, versionName, versionName)
 }
+
+func testAccDataSourceSendgridTemplateRenderConfig(templateName, versionName string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	name       = "%s"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "test" {
+	template_id            = sendgrid_template.test.id
+	name                   = "%s"
+	subject                = "Hello {{first_name}}"
+	html_content           = "<html><body>Welcome {{first_name}}</body></html>"
+	generate_plain_content = true
+	active                 = 1
+	test_data              = jsonencode({ first_name = "Ada" })
+}
+
+data "sendgrid_template_render" "test" {
+	template_id = sendgrid_template.test.id
+	version_id  = sendgrid_template_version.test.id
+}
+
+data "sendgrid_template_render" "override" {
+	template_id = sendgrid_template.test.id
+	version_id  = sendgrid_template_version.test.id
+	test_data   = jsonencode({ first_name = "Grace" })
+}
+`, templateName, versionName)
+}
+
+func testAccDataSourceSendgridTemplatesConfig(prefix string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	count      = 2
+	name       = "%s-${count.index}"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "test" {
+	count                  = 2
+	template_id            = sendgrid_template.test[count.index].id
+	name                   = "%s-${count.index}"
+	subject                = "Test Subject"
+	html_content           = "<html><body>Test</body></html>"
+	generate_plain_content = true
+	active                 = 1
+}
+
+data "sendgrid_templates" "test" {
+	depends_on = [sendgrid_template_version.test]
+	name_regex = "^%s-[0-9]$"
+}
+
+data "sendgrid_templates" "legacy" {
+	depends_on = [sendgrid_template_version.test]
+	generation = "legacy"
+	name_regex = "^%s-"
+}
+`, prefix, prefix, prefix, prefix)
+}
+
+func testAccDataSourceSendgridAPIKeysConfig(prefix string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_api_key" "test" {
+	count  = 2
+	name   = "%s-${count.index}"
+	scopes = count.index == 0 ? ["mail.send", "sender_verification_eligible"] : ["templates.read", "sender_verification_eligible"]
+}
+
+data "sendgrid_api_keys" "test" {
+	depends_on  = [sendgrid_api_key.test]
+	name_prefix = "%s-"
+}
+
+data "sendgrid_api_keys" "templates" {
+	depends_on  = [sendgrid_api_key.test]
+	name_prefix = "%s-"
+	scope       = "templates.read"
+}
+`, prefix, prefix, prefix)
+}
@@ -0,0 +1,141 @@
+package sendgrid
+
+import (
+	"testing"
+	"time"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestDNSValidationDiagnostics(t *testing.T) {
+	result := &sendgrid.DNSValidation{
+		Valid: false,
+		ValidationResults: map[string]sendgrid.DNSValidationResult{
+			"mail_cname": {Valid: true},
+			"dkim2":      {Valid: false},
+			"dkim1":      {Valid: false, Reason: "Expected CNAME to match \"s1.domainkey.u1.wl.sendgrid.net.\"."},
+		},
+	}
+
+	diags := dnsValidationDiagnostics("domain authentication", 10*time.Minute, result)
+
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %v", len(diags), diags)
+	}
+
+	want := []struct {
+		summary string
+		detail  string
+	}{
+		{summary: "The domain authentication DNS records are still not valid after 10m0s"},
+		{summary: "The dkim1 DNS record is not valid", detail: "Expected CNAME to match \"s1.domainkey.u1.wl.sendgrid.net.\"."},
+		{summary: "The dkim2 DNS record is not valid", detail: "SendGrid did not report a reason."},
+	}
+
+	for i, w := range want {
+		if diags[i].Severity != diag.Error {
+			t.Errorf("diagnostic %d: expected an error, got %v", i, diags[i].Severity)
+		}
+
+		if diags[i].Summary != w.summary {
+			t.Errorf("diagnostic %d: expected summary %q, got %q", i, w.summary, diags[i].Summary)
+		}
+
+		if w.detail != "" && diags[i].Detail != w.detail {
+			t.Errorf("diagnostic %d: expected detail %q, got %q", i, w.detail, diags[i].Detail)
+		}
+	}
+}
+
+func TestDNSValidationDiagnosticsWithoutResult(t *testing.T) {
+	diags := dnsValidationDiagnostics("link branding", time.Minute, nil)
+
+	if len(diags) != 1 || !diags.HasError() {
+		t.Fatalf("expected a single error, got %v", diags)
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "30s", wantErr: false},
+		{value: "1h15m", wantErr: false},
+		{value: "0s", wantErr: true},
+		{value: "-5m", wantErr: true},
+		{value: "ten minutes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			diags := validateDuration(tt.value, cty.Path{})
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validateDuration(%q) error = %v, wantErr %v", tt.value, diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNonNegativeDuration(t *testing.T) {
+	for value, wantErr := range map[string]bool{"0s": false, "24h": false, "-1s": true, "a day": true} {
+		if diags := validateNonNegativeDuration(value, cty.Path{}); diags.HasError() != wantErr {
+			t.Errorf("validateNonNegativeDuration(%q) error = %v, wantErr %v", value, diags, wantErr)
+		}
+	}
+}
+
+func TestFlattenDNSRecords(t *testing.T) {
+	records := []dnsRecord{
+		{role: "mail_cname", value: sendgrid.DNSRecordValue{Type: "cname", Host: "em.example.com", Data: "u1.wl.sendgrid.net"}},
+		{role: "dkim1", value: sendgrid.DNSRecordValue{Type: "cname", Host: "s1._domainkey.example.com", Data: "s1.domainkey.u1.wl.sendgrid.net", Valid: true}},
+		{role: "mail_server", value: sendgrid.DNSRecordValue{}},
+	}
+
+	flattened := flattenDNSRecords(records)
+
+	if len(flattened) != 2 {
+		t.Fatalf("expected 2 records, got %d: %v", len(flattened), flattened)
+	}
+
+	first := flattened[0].(map[string]interface{})
+	if first["role"] != "mail_cname" || first["type"] != "CNAME" || first["host"] != "em.example.com" {
+		t.Errorf("unexpected first record: %v", first)
+	}
+
+	second := flattened[1].(map[string]interface{})
+	if second["role"] != "dkim1" || second["valid"] != true {
+		t.Errorf("unexpected second record: %v", second)
+	}
+}
+
+func TestSetDNSRecordMaps(t *testing.T) {
+	records := []dnsRecord{
+		{role: "mail_cname", value: sendgrid.DNSRecordValue{Type: "cname", Host: "em.example.com", Data: "u1.wl.sendgrid.net"}},
+		{role: "dkim1", value: sendgrid.DNSRecordValue{Type: "cname", Host: "s1._domainkey.example.com", Valid: true}},
+		{role: "mail_server", value: sendgrid.DNSRecordValue{}},
+	}
+
+	d := resourceSendgridDomainAuthentication().TestResourceData()
+	if err := setDNSRecordMaps(d, records); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := d.Get("dns_record_types.mail_cname"); got != "CNAME" {
+		t.Errorf("mail_cname type = %v, want CNAME", got)
+	}
+
+	if got := d.Get("dns_record_hosts.dkim1"); got != "s1._domainkey.example.com" {
+		t.Errorf("dkim1 host = %v, want s1._domainkey.example.com", got)
+	}
+
+	if got := d.Get("dns_record_data.mail_cname"); got != "u1.wl.sendgrid.net" {
+		t.Errorf("mail_cname data = %v, want u1.wl.sendgrid.net", got)
+	}
+
+	if _, ok := d.Get("dns_record_hosts").(map[string]interface{})["mail_server"]; ok {
+		t.Error("mail_server host is set, want no record")
+	}
+}
@@ -13,12 +13,19 @@ var (
 	// doesn't have the good format.
 	ErrInvalidImportFormat = errors.New("invalid import. Supported import format: {{templateID}}/{{templateVersionID}}")
 
+	// ErrInvalidSubuserAssociationImportFormat error displayed when the string passed to import
+	// an association with a subuser doesn't have the good format.
+	ErrInvalidSubuserAssociationImportFormat = errors.New("invalid import. Supported import format: {{id}}/{{username}}")
+
 	// ErrSubUserNotFound error displayed when the subUser can not be found.
 	ErrSubUserNotFound = errors.New("subUser wasn't found")
 
 	// ErrNoNewVersionFoundForTemplate error displayed when no recent version can be found for a given template.
 	ErrNoNewVersionFoundForTemplate = errors.New("no recent version found for template_id")
 
+	// ErrTemplateNoActiveVersion error displayed when a template has no active version to render.
+	ErrTemplateNoActiveVersion = errors.New("template has no active version")
+
 	// ErrSetTemplateName error displayed when the provider can't set the template name.
 	ErrSetTemplateName = errors.New("could not set template name")
 
@@ -62,6 +69,77 @@ var (
 	// ErrSetTemplateVersionThumbnailURL error displayed when the provider can't set the template version thumbnail URL.
 	ErrSetTemplateVersionThumbnailURL = errors.New("could not set template version thumbnail URL")
 
+	// ErrSetTemplateVersionContentSHA256 error displayed when the provider
+	// can't set the template version content_sha256 attribute.
+	ErrSetTemplateVersionContentSHA256 = errors.New("could not set template version content_sha256 attribute")
+
+	// ErrSetTemplateVersionTestData error displayed when the provider
+	// can't set the template version test_data attribute.
+	ErrSetTemplateVersionTestData = errors.New("could not set template version test_data attribute")
+
+	// ErrTemplateVersionGeneratedPlainContent error displayed when a plain content is given
+	// while the provider is asked to generate it.
+	ErrTemplateVersionGeneratedPlainContent = errors.New(
+		"plain_content and plain_content_file require generate_plain_content to be false, " +
+			"otherwise the plain content is generated from the HTML content")
+
+	// ErrSetTemplateActiveVersionID error displayed when the provider
+	// can't set the active_version_id attribute of a template.
+	ErrSetTemplateActiveVersionID = errors.New("could not set template active_version_id attribute")
+
+	// ErrTemplateActiveVersionNoPrevious error displayed when a rollback is requested
+	// while no version was active before the current one.
+	ErrTemplateActiveVersionNoPrevious = errors.New(
+		"rollback requires previous_version_id, which is only known once version_id has been switched")
+
+	// ErrDesignGeneratedPlainContent error displayed when the plain content of a design is given
+	// while the provider is asked to generate it.
+	ErrDesignGeneratedPlainContent = errors.New(
+		"plain_content requires generate_plain_content to be false, " +
+			"otherwise the plain content is generated from the HTML content")
+
+	// ErrDesignNotFound error displayed when no design matches the given filters.
+	ErrDesignNotFound = errors.New("no design found")
+
+	// ErrWriteAPIKeySink error displayed when a new API key can't be delivered to its sink.
+	ErrWriteAPIKeySink = errors.New("could not deliver the API key to its sink, the key was deleted")
+
+	// ErrUnknownScopePreset error displayed when a scope preset doesn't exist.
+	ErrUnknownScopePreset = errors.New("unknown scope preset")
+
+	// ErrScopePatternNoMatch error displayed when a scope pattern doesn't match any scope the provider can grant.
+	ErrScopePatternNoMatch = errors.New("no scope matches")
+
+	// ErrTeammateAdminSubuserAccess error displayed when an admin teammate is restricted to some subusers.
+	ErrTeammateAdminSubuserAccess = errors.New("admin teammates have access to all the subusers, subuser_access requires is_admin to be false")
+
+	// ErrTeammateSubuserScopesRequired error displayed when a restricted access to a subuser has no scopes.
+	ErrTeammateSubuserScopesRequired = errors.New("the restricted permission_type requires scopes")
+
+	// ErrTeammateSubuserAdminScopes error displayed when an admin access to a subuser has scopes.
+	ErrTeammateSubuserAdminScopes = errors.New("the admin permission_type has all the scopes of the subuser, scopes must be empty")
+
+	// ErrTeammateAdminScopes error displayed when an admin teammate is given scopes.
+	ErrTeammateAdminScopes = errors.New("admin teammates have all the scopes, scopes must be empty")
+
+	// ErrTeammateDuplicateEmail error displayed when several teammate blocks have the same email.
+	ErrTeammateDuplicateEmail = errors.New("several teammate blocks have this email")
+
+	// ErrSSOMappingCSVHeader error displayed when the CSV of the users of an identity provider has no email column.
+	ErrSSOMappingCSVHeader = errors.New("the first line of the CSV of the users must name its columns, including email")
+
+	// ErrSSOMappingUserEmail error displayed when a user of an identity provider has no email.
+	ErrSSOMappingUserEmail = errors.New("the users of the identity provider require an email")
+
+	// ErrSSOMappingDuplicateUser error displayed when a user of an identity provider is given twice.
+	ErrSSOMappingDuplicateUser = errors.New("duplicate user")
+
+	// ErrSSOMappingNoUsers error displayed when the export of the users of an identity provider has no user.
+	ErrSSOMappingNoUsers = errors.New("the export of the users has no user, set allow_remove_all to remove all the teammates")
+
+	// ErrSSOMappingUserNames error displayed when a user matching a group rule has no first or last name.
+	ErrSSOMappingUserNames = errors.New("SSO teammates require a first_name and a last_name")
+
 	// ErrSetUnsubscribeGroupName error displayed when the provider can't set the unsubscribe group name.
 	ErrSetUnsubscribeGroupName = errors.New("could not set unsubscribe group name")
 
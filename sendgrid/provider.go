@@ -5,13 +5,19 @@ API key Resource
 
 	sendgrid_api_key
 
-Domain authentication Resource
+Design Library Resource
+
+	sendgrid_design
+
+Domain authentication Resources
 
 	sendgrid_domain_authentication
+	sendgrid_domain_authentication_subuser
 
-Link branding Resource
+Link branding Resources
 
 	sendgrid_link_branding
+	sendgrid_link_branding_subuser
 
 SSO Resources
 
@@ -25,6 +31,9 @@ Subuser Resource
 Template Resources
 
 	sendgrid_template
+	sendgrid_template_active_version
+	sendgrid_template_copy
+	sendgrid_template_test_send
 	sendgrid_template_version
 
 Unsubscribe Group Resource
@@ -40,6 +49,7 @@ package sendgrid
 
 import (
 	"context"
+	"sync"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -56,16 +66,33 @@ type Config struct {
 	APIKey  string
 	Host    string
 	Subuser string
+	// RefreshScopes retrieves the scope catalog from SendGrid instead of using the embedded one.
+	RefreshScopes bool
+
+	scopesMutex   sync.Mutex
+	scopeCatalogs map[string]*scopeCatalog
+
+	usernamesOnce sync.Once
+	usernames     *sendgrid.UsernameCache
 }
 
 // NewClient creates a new SendGrid client from the config.
 // This should be called for each operation to avoid shared state issues.
+// The clients share the usernames of the teammates, so that they're listed once per run.
 func (c *Config) NewClient(onBehalfOf string) *sendgrid.Client {
 	subuser := c.Subuser
 	if onBehalfOf != "" {
 		subuser = onBehalfOf
 	}
-	return sendgrid.NewClient(c.APIKey, c.Host, subuser)
+
+	c.usernamesOnce.Do(func() {
+		c.usernames = sendgrid.NewUsernameCache()
+	})
+
+	client := sendgrid.NewClient(c.APIKey, c.Host, subuser)
+	client.Usernames = c.usernames
+
+	return client
 }
 
 // Provider terraform.ResourceProvider.
@@ -88,29 +115,49 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SENDGRID_SUBUSER", nil),
 			},
+			"refresh_scopes": {
+				Type: schema.TypeBool,
+				Description: "Whether the scopes of the API key are retrieved once per run, " +
+					"to validate the scopes of the API keys and teammates at plan time. " +
+					"The embedded scope catalog is used otherwise, or when they can't be retrieved.",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SENDGRID_REFRESH_SCOPES", true),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
+			"sendgrid_api_keys":          dataSendgridAPIKeys(),
 			"sendgrid_template":          dataSendgridTemplate(),
+			"sendgrid_templates":         dataSendgridTemplates(),
 			"sendgrid_template_version":  dataSendgridTemplateVersion(),
+			"sendgrid_template_render":   dataSendgridTemplateRender(),
+			"sendgrid_prebuilt_design":   dataSendgridPrebuiltDesign(),
 			"sendgrid_unsubscribe_group": dataSendgridUnsubscribeGroup(),
 			"sendgrid_teammate":          dataSendgridTeammate(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"sendgrid_api_key":                 resourceSendgridAPIKey(),
-			"sendgrid_subuser":                 resourceSendgridSubuser(),
-			"sendgrid_template":                resourceSendgridTemplate(),
-			"sendgrid_template_version":        resourceSendgridTemplateVersion(),
-			"sendgrid_unsubscribe_group":       resourceSendgridUnsubscribeGroup(),
-			"sendgrid_parse_webhook":           resourceSendgridParseWebhook(),
-			"sendgrid_event_webhook":           resourceSendgridEventWebhook(),
-			"sendgrid_domain_authentication":   resourceSendgridDomainAuthentication(),
-			"sendgrid_link_branding":           resourceSendgridLinkBranding(),
-			"sendgrid_sso_integration":         resourceSendgridSSOIntegration(),
-			"sendgrid_sso_certificate":         resourceSendgridSSOCertificate(),
-			"sendgrid_teammate":                resourceSendgridTeammate(),
-			"sendgrid_webhook_security_policy": resourceSendgridWebhookSecurityPolicy(),
+			"sendgrid_api_key":                       resourceSendgridAPIKey(),
+			"sendgrid_subuser":                       resourceSendgridSubuser(),
+			"sendgrid_template":                      resourceSendgridTemplate(),
+			"sendgrid_template_version":              resourceSendgridTemplateVersion(),
+			"sendgrid_template_active_version":       resourceSendgridTemplateActiveVersion(),
+			"sendgrid_template_copy":                 resourceSendgridTemplateCopy(),
+			"sendgrid_template_test_send":            resourceSendgridTemplateTestSend(),
+			"sendgrid_design":                        resourceSendgridDesign(),
+			"sendgrid_unsubscribe_group":             resourceSendgridUnsubscribeGroup(),
+			"sendgrid_parse_webhook":                 resourceSendgridParseWebhook(),
+			"sendgrid_event_webhook":                 resourceSendgridEventWebhook(),
+			"sendgrid_domain_authentication":         resourceSendgridDomainAuthentication(),
+			"sendgrid_domain_authentication_subuser": resourceSendgridDomainAuthenticationSubuser(),
+			"sendgrid_link_branding":                 resourceSendgridLinkBranding(),
+			"sendgrid_link_branding_subuser":         resourceSendgridLinkBrandingSubuser(),
+			"sendgrid_sso_integration":               resourceSendgridSSOIntegration(),
+			"sendgrid_sso_certificate":               resourceSendgridSSOCertificate(),
+			"sendgrid_sso_teammate_mapping":          resourceSendgridSSOTeammateMapping(),
+			"sendgrid_teammate":                      resourceSendgridTeammate(),
+			"sendgrid_teammates":                     resourceSendgridTeammates(),
+			"sendgrid_webhook_security_policy":       resourceSendgridWebhookSecurityPolicy(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
@@ -137,9 +184,10 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 	subuser := d.Get("subuser").(string)
 
 	config := &Config{
-		APIKey:  apiKey,
-		Host:    host,
-		Subuser: subuser,
+		APIKey:        apiKey,
+		Host:          host,
+		Subuser:       subuser,
+		RefreshScopes: d.Get("refresh_scopes").(bool),
 	}
 
 	return config, diags
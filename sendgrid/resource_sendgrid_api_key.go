@@ -11,6 +11,36 @@ Example Usage
 		]
 	}
 
+```
+Rotation
+With a rotation block, a successor key is created when rotate_after has elapsed or when a keeper changes,
+and the predecessor stays valid until the end of the grace period, e.g.
+```hcl
+
+	resource "sendgrid_api_key" "api_key" {
+		name   = "my-api-key"
+		scopes = ["mail.send"]
+
+		rotation {
+			rotate_after = "720h"
+			grace_period = "24h"
+		}
+	}
+
+```
+Sink
+With a sink block, the key is written to a file or given to a command instead of being stored in the state, e.g.
+```hcl
+
+	resource "sendgrid_api_key" "api_key" {
+		name   = "my-api-key"
+		scopes = ["mail.send"]
+
+		sink {
+			command = "vault kv put secret/sendgrid api_key=-"
+		}
+	}
+
 ```
 Import
 An API key can be imported, e.g.
@@ -22,7 +52,17 @@ package sendgrid
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
+	"time"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -32,6 +72,8 @@ import (
 
 func resourceSendgridAPIKey() *schema.Resource {
 	return &schema.Resource{
+		Description: "Manages an API key, optionally rotated without downtime: " +
+			"the key it replaces stays valid during a grace period.",
 		CreateContext: resourceSendgridAPIKeyCreate,
 		ReadContext:   resourceSendgridAPIKeyRead,
 		UpdateContext: resourceSendgridAPIKeyUpdate,
@@ -39,6 +81,7 @@ func resourceSendgridAPIKey() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceSendgridAPIKeyCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -48,12 +91,16 @@ func resourceSendgridAPIKey() *schema.Resource {
 				ValidateFunc: validation.StringLenBetween(1, maxStringLength),
 			},
 			"scopes": {
-				Type:        schema.TypeSet,
-				Description: "The individual permissions that you are giving to this API Key.",
-				Optional:    true,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeSet,
+				Description: "The individual permissions that you are giving to this API Key. " +
+					"They're validated at plan time, and must be scopes the API key of the provider has. " +
+					"SendGrid adds `sender_verification_eligible` and `2fa_required` to the key.",
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"scope_presets":  scopePresetsSchema(),
+			"scope_patterns": scopePatternsSchema(),
 			"api_key": {
 				Type:        schema.TypeString,
 				Description: "The API key created by the API.",
@@ -65,6 +112,101 @@ func resourceSendgridAPIKey() *schema.Resource {
 				Description: "The subuser's username. The API call is made on behalf of the subuser account.",
 				Optional:    true,
 			},
+			"rotation": {
+				Type: schema.TypeList,
+				Description: "Rotates the key without downtime: a successor key is created, " +
+					"the key it replaces is kept as `previous_api_key` until the end of the grace period.",
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rotate_after": {
+							Type: schema.TypeString,
+							Description: "The age after which the key is rotated, as a duration, e.g. 720h. " +
+								"The rotation happens at the first apply after that age.",
+							Optional:         true,
+							ValidateDiagFunc: validateDuration,
+						},
+						"keepers": {
+							Type:        schema.TypeMap,
+							Description: "Arbitrary values whose changes rotate the key.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"grace_period": {
+							Type: schema.TypeString,
+							Description: "How long the predecessor stays valid after a rotation, as a duration, e.g. 24h. " +
+								"It is deleted at the first apply after the grace period, by default at the apply following the rotation.",
+							Optional:         true,
+							Default:          "0s",
+							ValidateDiagFunc: validateNonNegativeDuration,
+						},
+					},
+				},
+			},
+			"sink": {
+				Type: schema.TypeList,
+				Description: "Delivers the key to a file or a command instead of storing it in the state: " +
+					"`api_key` and `previous_api_key` are left empty. Changing the sink creates a new key, " +
+					"since the key can't be read again once created.",
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"file": {
+							Type:         schema.TypeString,
+							Description:  "The file where the key is written, readable by its owner only (0600).",
+							Optional:     true,
+							ForceNew:     true,
+							AtLeastOneOf: []string{"sink.0.file", "sink.0.command"},
+						},
+						"command": {
+							Type: schema.TypeString,
+							Description: "A command run by the shell, given the key on its standard input " +
+								"and its ID and name in the SENDGRID_API_KEY_ID and SENDGRID_API_KEY_NAME environment variables, " +
+								"e.g. to store the key in a secret manager.",
+							Optional:     true,
+							ForceNew:     true,
+							AtLeastOneOf: []string{"sink.0.file", "sink.0.command"},
+						},
+					},
+				},
+			},
+			"api_key_sha256": {
+				Type: schema.TypeString,
+				Description: "The SHA-256 of the key, which changes with each new key, " +
+					"e.g. to restart the services reading the key from a sink.",
+				Computed: true,
+			},
+			"previous_api_key": {
+				Type:        schema.TypeString,
+				Description: "The key replaced by the last rotation, until it is deleted at the end of the grace period.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"previous_api_key_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the key replaced by the last rotation, until it is deleted.",
+				Computed:    true,
+			},
+			"rotated_at": {
+				Type: schema.TypeString,
+				Description: "The date and time the key was created or last rotated, in RFC 3339 format. " +
+					"For keys created before this attribute, the date it was first read.",
+				Computed: true,
+			},
+			"rotation_due": {
+				Type: schema.TypeBool,
+				Description: "Whether `rotate_after` had elapsed at the last refresh: the key is rotated at the next apply. " +
+					"Like the changes of `rotate_after`, the elapsed time is only taken into account by a refresh.",
+				Computed: true,
+			},
+			"previous_api_key_expired": {
+				Type:        schema.TypeBool,
+				Description: "Whether the grace period of the previous key was over at the last refresh: it is deleted at the next apply.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -99,21 +241,50 @@ func resourceSendgridAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m
 	apiKeyStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
 		return c.CreateAPIKey(ctx, name, scopes)
 	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	apiKey := apiKeyStruct.(*sendgrid.APIKey)
 
-	if err != nil {
+	if err := setAPIKey(ctx, d, c, apiKey); err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(apiKey.ID)
 	//nolint:errcheck
-	d.Set("api_key", apiKey.APIKey)
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
 
 	return resourceSendgridAPIKeyRead(ctx, d, m)
 }
 
+// resourceSendgridAPIKeyRead reads the key, and whether it's time to rotate it or to delete its predecessor:
+// like time_rotating, the expiry is detected by the refresh, so that the plan only follows the state.
 func resourceSendgridAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := readAPIKey(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	newAPIKeyRotation(d.Get).setExpiry(d, time.Now())
+
+	return apiKeySinkDiagnostics(d)
+}
+
+// resourceSendgridAPIKeyReadAfterApply reads the key after an update, the expiry is only set when it was planned
+// as unknown, so that the result of the apply is the planned one.
+func resourceSendgridAPIKeyReadAfterApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := readAPIKey(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if plan := d.GetRawPlan(); !plan.IsNull() && !plan.GetAttr("previous_api_key_expired").IsKnown() {
+		newAPIKeyRotation(d.Get).setExpiry(d, time.Now())
+	}
+
+	return apiKeySinkDiagnostics(d)
+}
+
+func readAPIKey(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	onBehalfOf := d.Get("sub_user_on_behalf_of").(string)
 
@@ -121,7 +292,7 @@ func resourceSendgridAPIKeyRead(ctx context.Context, d *schema.ResourceData, m i
 
 	apiKey, err := c.ReadAPIKey(ctx, d.Id())
 	if err.Err != nil {
-		return diag.FromErr(err.Err)
+		return err.Err
 	}
 
 	//nolint:errcheck
@@ -129,9 +300,36 @@ func resourceSendgridAPIKeyRead(ctx context.Context, d *schema.ResourceData, m i
 	//nolint:errcheck
 	d.Set("scopes", apiKey.Scopes)
 
+	// The age of keys created before the rotation support is counted from their first read.
+	if d.Get("rotated_at").(string) == "" {
+		//nolint:errcheck
+		d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
 	return nil
 }
 
+// apiKeySinkDiagnostics warns when the file of the sink is gone: the key can't be written again.
+func apiKeySinkDiagnostics(d *schema.ResourceData) diag.Diagnostics {
+	sinks := d.Get("sink").([]interface{})
+	if len(sinks) == 0 || sinks[0] == nil {
+		return nil
+	}
+
+	file := sinks[0].(map[string]interface{})["file"].(string)
+	if _, err := os.Stat(file); file == "" || err == nil {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "API key file not found",
+		Detail: fmt.Sprintf("The file %q where API key %q was written doesn't exist anymore. "+
+			"The key isn't stored in the state and can't be written again: "+
+			"change a keeper of its rotation, or replace the resource, to get a new key.", file, d.Id()),
+	}}
+}
+
 func hasDiff(o, n interface{}) bool {
 	if eq, ok := o.(schema.Equal); ok {
 		return !eq.Equal(n)
@@ -146,14 +344,33 @@ func resourceSendgridAPIKeyUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	c := config.NewClient(onBehalfOf)
 
+	// The predecessor is deleted when its grace period is over, or before a new rotation replaces it.
+	oldPreviousID, newPreviousID := d.GetChange("previous_api_key_id")
+	if oldPreviousID.(string) != "" && d.HasChange("previous_api_key_id") {
+		if err := deleteAPIKey(ctx, d, c, oldPreviousID.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		//nolint:errcheck
+		d.Set("previous_api_key_id", newPreviousID)
+		//nolint:errcheck
+		d.Set("previous_api_key", "")
+	}
+
+	// A rotation is planned by the diff, which marks rotated_at as unknown.
+	if d.HasChange("rotated_at") {
+		return resourceSendgridAPIKeyRotate(ctx, d, m)
+	}
+
 	a := sendgrid.APIKey{
 		ID:   d.Id(),
 		Name: d.Get("name").(string),
 	}
 
 	o, n := d.GetChange("scopes")
-	n.(*schema.Set).Add("sender_verification_eligible")
-	n.(*schema.Set).Add("2fa_required")
+	for scope := range apiKeyAutomaticScopes {
+		n.(*schema.Set).Add(scope)
+	}
 
 	if ok := hasDiff(o, n); ok {
 		var scopes []string
@@ -171,7 +388,46 @@ func resourceSendgridAPIKeyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	return resourceSendgridAPIKeyRead(ctx, d, m)
+	return resourceSendgridAPIKeyReadAfterApply(ctx, d, m)
+}
+
+// resourceSendgridAPIKeyRotate creates the successor of the key, which becomes the previous key.
+func resourceSendgridAPIKeyRotate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient(d.Get("sub_user_on_behalf_of").(string))
+
+	scopes := []string{}
+	for _, scope := range d.Get("scopes").(*schema.Set).List() {
+		scopes = append(scopes, scope.(string))
+	}
+
+	if ok := scopeInScopes(scopes, "sender_verification_eligible"); !ok {
+		scopes = append(scopes, "sender_verification_eligible")
+	}
+
+	apiKeyStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.CreateAPIKey(ctx, d.Get("name").(string), scopes)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	apiKey := apiKeyStruct.(*sendgrid.APIKey)
+	previousAPIKey, _ := d.GetChange("api_key")
+
+	if err := setAPIKey(ctx, d, c, apiKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	//nolint:errcheck
+	d.Set("previous_api_key_id", d.Id())
+	//nolint:errcheck
+	d.Set("previous_api_key", previousAPIKey)
+	d.SetId(apiKey.ID)
+	//nolint:errcheck
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+
+	return resourceSendgridAPIKeyReadAfterApply(ctx, d, m)
 }
 
 func resourceSendgridAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -180,12 +436,215 @@ func resourceSendgridAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m
 
 	c := config.NewClient(onBehalfOf)
 
+	if previousID := d.Get("previous_api_key_id").(string); previousID != "" {
+		if err := deleteAPIKey(ctx, d, c, previousID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := deleteAPIKey(ctx, d, c, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// setAPIKey stores a new key in the state, or delivers it to the sink.
+// A key which couldn't be delivered is deleted, since nobody could ever use it.
+func setAPIKey(ctx context.Context, d *schema.ResourceData, c *sendgrid.Client, apiKey *sendgrid.APIKey) error {
+	hash := sha256.Sum256([]byte(apiKey.APIKey))
+
+	//nolint:errcheck
+	d.Set("api_key_sha256", hex.EncodeToString(hash[:]))
+
+	sinks := d.Get("sink").([]interface{})
+	if len(sinks) == 0 || sinks[0] == nil {
+		//nolint:errcheck
+		d.Set("api_key", apiKey.APIKey)
+
+		return nil
+	}
+
+	if err := writeAPIKeySink(ctx, sinks[0].(map[string]interface{}), apiKey); err != nil {
+		return errors.Join(err, deleteAPIKey(ctx, d, c, apiKey.ID))
+	}
+
+	//nolint:errcheck
+	d.Set("api_key", "")
+
+	return nil
+}
+
+// writeAPIKeySink writes the key to the file of the sink, then gives it to its command.
+func writeAPIKeySink(ctx context.Context, sink map[string]interface{}, apiKey *sendgrid.APIKey) error {
+	if file := sink["file"].(string); file != "" {
+		if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteAPIKeySink, err)
+		}
+
+		// WriteFile keeps the permissions of an existing file, they're restricted again.
+		if err := os.WriteFile(file, []byte(apiKey.APIKey), 0o600); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteAPIKeySink, err)
+		}
+
+		if err := os.Chmod(file, 0o600); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteAPIKeySink, err)
+		}
+	}
+
+	if command := sink["command"].(string); command != "" {
+		shell, flag := "sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+
+		cmd := exec.CommandContext(ctx, shell, flag, command) //nolint:gosec
+		cmd.Stdin = strings.NewReader(apiKey.APIKey)
+		cmd.Env = append(os.Environ(), "SENDGRID_API_KEY_ID="+apiKey.ID, "SENDGRID_API_KEY_NAME="+apiKey.Name)
+
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%w: %w: %s", ErrWriteAPIKeySink, err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
+func deleteAPIKey(ctx context.Context, d *schema.ResourceData, c *sendgrid.Client, id string) error {
 	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
-		return c.DeleteAPIKey(ctx, d.Id())
+		return c.DeleteAPIKey(ctx, id)
 	})
-	if err != nil {
-		return diag.FromErr(err)
+
+	return err
+}
+
+// resourceSendgridAPIKeyCustomizeDiff expands and validates the scopes of the key,
+// and plans its rotations and the deletion of its predecessor.
+func resourceSendgridAPIKeyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	config := m.(*Config)
+	catalog := config.scopeCatalog(ctx, diff.Get("sub_user_on_behalf_of").(string))
+
+	// The key gets the automatic scopes anyway, the planned scopes include the ones it has or will have.
+	automatic := []string{"sender_verification_eligible"}
+	if o, _ := diff.GetChange("scopes"); o.(*schema.Set).Contains("2fa_required") {
+		automatic = append(automatic, "2fa_required")
+	}
+
+	if _, err := expandScopesDiff(diff, catalog, apiKeyAutomaticScopes, automatic); err != nil {
+		return err
+	}
+
+	if (diff.Id() == "" || diff.HasChange("scopes")) && diff.NewValueKnown("scopes") {
+		// The automatic scopes are kept in the state, they're not an error.
+		scopes := removeScopes(scopesFromSet(diff.Get("scopes").(*schema.Set)), apiKeyAutomaticScopes)
+		if err := catalog.check(scopes, nil).err(); err != nil {
+			return err
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	rotation := apiKeyRotationFromDiff(diff)
+	rotate, deletePrevious := rotation.plan()
+
+	if deletePrevious {
+		for _, key := range []string{"previous_api_key_id", "previous_api_key"} {
+			if err := diff.SetNew(key, ""); err != nil {
+				return err
+			}
+		}
+
+		return diff.SetNew("previous_api_key_expired", false)
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	for _, key := range []string{
+		"api_key", "previous_api_key", "previous_api_key_id", "rotated_at", "rotation_due", "previous_api_key_expired",
+	} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// apiKeyRotation is the state of the rotation of a key.
+type apiKeyRotation struct {
+	enabled        bool
+	rotateAfter    time.Duration
+	gracePeriod    time.Duration
+	keepersChanged bool
+	rotatedAt      time.Time
+	hasPrevious    bool
+	// rotationDue and previousExpired are the expiry detected by the last refresh.
+	rotationDue     bool
+	previousExpired bool
+}
+
+func newAPIKeyRotation(get func(string) interface{}) apiKeyRotation {
+	rotation := apiKeyRotation{
+		hasPrevious:     get("previous_api_key_id").(string) != "",
+		rotationDue:     get("rotation_due").(bool),
+		previousExpired: get("previous_api_key_expired").(bool),
+	}
+
+	// A key without a known creation date is not rotated because of its age.
+	rotation.rotatedAt, _ = time.Parse(time.RFC3339, get("rotated_at").(string))
+
+	blocks := get("rotation").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		// Without rotation, the predecessor of a former rotation is deleted right away.
+		return rotation
+	}
+
+	block := blocks[0].(map[string]interface{})
+	rotation.enabled = true
+	rotation.rotateAfter, _ = time.ParseDuration(block["rotate_after"].(string))
+	rotation.gracePeriod, _ = time.ParseDuration(block["grace_period"].(string))
+
+	return rotation
+}
+
+func apiKeyRotationFromDiff(diff *schema.ResourceDiff) apiKeyRotation {
+	rotation := newAPIKeyRotation(diff.Get)
+
+	// Adding a rotation block to an existing key isn't a change of its keepers.
+	if o, n := diff.GetChange("rotation.0.keepers"); len(o.(map[string]interface{})) > 0 {
+		rotation.keepersChanged = !reflect.DeepEqual(o, n)
+	}
+
+	return rotation
+}
+
+// expiry returns whether rotate_after has elapsed and whether the grace period of the predecessor is over.
+func (r apiKeyRotation) expiry(now time.Time) (bool, bool) {
+	rotationDue := r.enabled && r.rotateAfter > 0 && !r.rotatedAt.IsZero() && !now.Before(r.rotatedAt.Add(r.rotateAfter))
+	previousExpired := r.hasPrevious && (r.rotatedAt.IsZero() || !now.Before(r.rotatedAt.Add(r.gracePeriod)))
+
+	return rotationDue, previousExpired
+}
+
+func (r apiKeyRotation) setExpiry(d *schema.ResourceData, now time.Time) {
+	rotationDue, previousExpired := r.expiry(now)
+
+	//nolint:errcheck
+	d.Set("rotation_due", rotationDue)
+	//nolint:errcheck
+	d.Set("previous_api_key_expired", previousExpired)
+}
+
+// plan returns whether the key is rotated and whether its predecessor is deleted, from the expiry of the last refresh.
+func (r apiKeyRotation) plan() (bool, bool) {
+	rotate := r.enabled && (r.keepersChanged || r.rotationDue)
+
+	// A new rotation replaces the predecessor, which is deleted first, whatever its grace period.
+	deletePrevious := r.hasPrevious && !rotate && (!r.enabled || r.previousExpired)
+
+	return rotate, deletePrevious
+}
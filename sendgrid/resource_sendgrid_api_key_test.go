@@ -3,6 +3,8 @@ package sendgrid_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
@@ -30,6 +32,84 @@ func TestAccSendgridAPIKeyBasic(t *testing.T) {
 	})
 }
 
+func TestAccSendgridAPIKeyRotation(t *testing.T) {
+	name := "terraform-api-key-" + acctest.RandString(10)
+	resourceName := "sendgrid_api_key.rotated"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridAPIKeyConfigRotation(name, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "api_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "rotated_at"),
+					resource.TestCheckResourceAttr(resourceName, "previous_api_key_id", ""),
+				),
+			},
+			{
+				Config: testAccCheckSendgridAPIKeyConfigRotation(name, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "api_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "previous_api_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "previous_api_key_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridAPIKeyConfigRotation(name, generation string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_api_key" "rotated" {
+	name   = "%s"
+	scopes = ["mail.send"]
+
+	rotation {
+		keepers = {
+			generation = "%s"
+		}
+		grace_period = "1h"
+	}
+}
+`, name, generation)
+}
+
+func TestAccSendgridAPIKeySinkFile(t *testing.T) {
+	name := "terraform-api-key-" + acctest.RandString(10)
+	file := filepath.Join(t.TempDir(), "api_key")
+	resourceName := "sendgrid_api_key.sink"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "sendgrid_api_key" "sink" {
+	name   = "%s"
+	scopes = ["mail.send"]
+
+	sink {
+		file = "%s"
+	}
+}
+`, name, filepath.ToSlash(file)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "api_key", ""),
+					resource.TestCheckResourceAttrSet(resourceName, "api_key_sha256"),
+					func(*terraform.State) error {
+						_, err := os.Stat(file)
+
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSendgridAPIKeyDestroy(s *terraform.State) error {
 	c := testAccProvider.Meta().(*sendgrid.Client)
 
@@ -113,3 +193,30 @@ resource "sendgrid_api_key" "onbehalf" {
 }
 `, username, email, password, keyName)
 }
+
+func TestAccSendgridAPIKeyScopePatterns(t *testing.T) {
+	name := "terraform-api-key-" + acctest.RandString(10)
+	resourceName := "sendgrid_api_key.patterns"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "sendgrid_api_key" "patterns" {
+  name           = "%s"
+  scope_presets  = ["mail_send_only"]
+  scope_patterns = ["templates.versions.*"]
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(resourceName, "scopes.*", "mail.send"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "scopes.*", "templates.versions.read"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "scopes.*", "templates.versions.activate.update"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,253 @@
+/*
+Provide a resource to manage a design of the Design Library.
+Example Usage
+```hcl
+
+	resource "sendgrid_design" "footer" {
+		name         = "footer"
+		html_content = file("${path.module}/designs/footer.html")
+		categories   = ["footer", "legal"]
+	}
+
+```
+Import
+A design can be imported, e.g.
+```hcl
+$ terraform import sendgrid_design.footer designID
+```
+*/
+package sendgrid
+
+import (
+	"context"
+
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/plaintext"
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSendgridDesign() *schema.Resource { //nolint:funlen
+	return &schema.Resource{
+		Description: "Manages a design of the Design Library. Designs are shared by marketing campaigns " +
+			"and can be duplicated into transactional template versions with `design_id`.",
+		CreateContext: resourceSendgridDesignCreate,
+		ReadContext:   resourceSendgridDesignRead,
+		UpdateContext: resourceSendgridDesignUpdate,
+		DeleteContext: resourceSendgridDesignDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSendgridDesignImport,
+		},
+		CustomizeDiff: resourceSendgridDesignCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the design, max length: 100.",
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, maxStringLength),
+			},
+			"editor": {
+				Type:         schema.TypeString,
+				Description:  "The editor used in the UI, allowed values: code (default), design.",
+				Optional:     true,
+				Default:      "code",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"code", "design"}, false),
+			},
+			"html_content": {
+				Type:        schema.TypeString,
+				Description: "The HTML content of the design, maximum of 1048576 bytes allowed.",
+				Required:    true,
+			},
+			"plain_content": {
+				Type: schema.TypeString,
+				Description: "The plain content of the design, maximum of 1048576 bytes allowed, " +
+					"requires `generate_plain_content` to be false.",
+				Optional: true,
+				Computed: true,
+			},
+			"generate_plain_content": {
+				Type: schema.TypeBool,
+				Description: "If true (default), plain_content is generated from html_content by the provider, " +
+					"like for template versions. If false, plain_content is not altered.",
+				Optional: true,
+				Default:  true,
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Description: "The subject of the design.",
+				Optional:    true,
+			},
+			"categories": {
+				Type:        schema.TypeSet,
+				Description: "The categories of the design, used to filter designs in the Design Library.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"thumbnail_url": {
+				Type:        schema.TypeString,
+				Description: "A thumbnail preview of the design's HTML content.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time the design was created.",
+				Computed:    true,
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of the last update of the design.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSendgridDesignCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	design := sendgridDesignFromResourceData(d)
+	design.Editor = d.Get("editor").(string)
+
+	designStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.CreateDesign(ctx, design)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(designStruct.(*sendgrid.Design).ID)
+
+	return resourceSendgridDesignRead(ctx, d, m)
+}
+
+func resourceSendgridDesignRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	designStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadDesign(ctx, d.Id())
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parseDesign(d, designStruct.(*sendgrid.Design))
+
+	return nil
+}
+
+func parseDesign(d *schema.ResourceData, design *sendgrid.Design) {
+	//nolint:errcheck
+	d.Set("name", design.Name)
+	//nolint:errcheck
+	d.Set("editor", design.Editor)
+	//nolint:errcheck
+	d.Set("html_content", design.HTMLContent)
+	//nolint:errcheck
+	d.Set("plain_content", design.PlainContent)
+	//nolint:errcheck
+	d.Set("subject", design.Subject)
+	//nolint:errcheck
+	d.Set("categories", design.Categories)
+	//nolint:errcheck
+	d.Set("thumbnail_url", design.ThumbnailURL)
+	//nolint:errcheck
+	d.Set("created_at", design.CreatedAt)
+	//nolint:errcheck
+	d.Set("updated_at", design.UpdatedAt)
+}
+
+func resourceSendgridDesignUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	design := sendgridDesignFromResourceData(d)
+	design.ID = d.Id()
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.UpdateDesign(ctx, design)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSendgridDesignRead(ctx, d, m)
+}
+
+func resourceSendgridDesignDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.DeleteDesign(ctx, d.Id())
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSendgridDesignImport(
+	_ context.Context,
+	d *schema.ResourceData,
+	_ interface{},
+) ([]*schema.ResourceData, error) {
+	//nolint:errcheck
+	d.Set("generate_plain_content", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceSendgridDesignCustomizeDiff plans the plain content generated from the HTML content.
+func resourceSendgridDesignCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.Get("generate_plain_content").(bool) {
+		return nil
+	}
+
+	if !diff.GetRawConfig().GetAttr("plain_content").IsNull() {
+		return ErrDesignGeneratedPlainContent
+	}
+
+	if !diff.NewValueKnown("html_content") {
+		return diff.SetNewComputed("plain_content")
+	}
+
+	plainContent := plaintext.FromHTML(diff.Get("html_content").(string))
+	if diff.Get("plain_content").(string) != plainContent {
+		return diff.SetNew("plain_content", plainContent)
+	}
+
+	return nil
+}
+
+// sendgridDesignFromResourceData returns the design sent to SendGrid,
+// with the plain content already generated when requested.
+func sendgridDesignFromResourceData(d *schema.ResourceData) sendgrid.Design {
+	htmlContent := d.Get("html_content").(string)
+
+	plainContent := d.Get("plain_content").(string)
+	if d.Get("generate_plain_content").(bool) {
+		plainContent = plaintext.FromHTML(htmlContent)
+	}
+
+	categories := []string{}
+	for _, category := range d.Get("categories").(*schema.Set).List() {
+		categories = append(categories, category.(string))
+	}
+
+	return sendgrid.Design{
+		Name:                 d.Get("name").(string),
+		HTMLContent:          htmlContent,
+		PlainContent:         plainContent,
+		GeneratePlainContent: false,
+		Subject:              d.Get("subject").(string),
+		Categories:           categories,
+	}
+}
@@ -0,0 +1,80 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridDesignBasic(t *testing.T) {
+	name := "terraform-design-" + acctest.RandString(10)
+	resourceName := "sendgrid_design.new"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridDesignConfig(name, "<p>Follow <a href=\\\"https://example.com\\\">us</a></p>"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "plain_content", "Follow us (https://example.com)"),
+					resource.TestCheckResourceAttr(resourceName, "categories.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "updated_at"),
+					resource.TestCheckResourceAttrPair(
+						"sendgrid_template_version.from_design", "html_content", resourceName, "html_content"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSendgridPrebuiltDesign(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "sendgrid_prebuilt_design" "test" {
+	name = "Ingrid"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sendgrid_prebuilt_design.test", "id"),
+					resource.TestCheckResourceAttrSet("data.sendgrid_prebuilt_design.test", "html_content"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridDesignConfig(name, htmlContent string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_design" "new" {
+	name         = "%s"
+	html_content = "%s"
+	categories   = ["footer", "legal"]
+}
+
+resource "sendgrid_template" "template" {
+	name       = "%s"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "from_design" {
+	name        = "from-design"
+	template_id = sendgrid_template.template.id
+	subject     = "subject"
+	design_id   = sendgrid_design.new.id
+	depends_on  = [sendgrid_design.new]
+}
+`, name, htmlContent, name)
+}
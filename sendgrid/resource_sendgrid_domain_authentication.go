@@ -8,6 +8,11 @@ Example Usage
 	    ips = [ "10.10.10.10" ]
 	    is_default = true
 	    automatic_security = false
+
+	    wait_for_validation {
+	        timeout  = "15m"
+	        interval = "30s"
+	    }
 	}
 
 ```
@@ -22,8 +27,13 @@ package sendgrid
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -37,6 +47,7 @@ func resourceSendgridDomainAuthentication() *schema.Resource { //nolint:funlen
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceSendgridDNSValidationCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"domain": {
@@ -95,6 +106,14 @@ func resourceSendgridDomainAuthentication() *schema.Resource { //nolint:funlen
 				Optional:    true,
 				Computed:    true,
 			},
+			"wait_for_validation": waitForValidationSchema(),
+			"dns_records": dnsRecordsSchema(
+				"The DNS records used to authenticate the sending domain, in a stable order and identified by `role`: " +
+					domainAuthenticationDNSRoles + ".",
+			),
+			"dns_record_hosts": dnsRecordMapSchema("host", domainAuthenticationDNSRoles),
+			"dns_record_types": dnsRecordMapSchema("type", domainAuthenticationDNSRoles),
+			"dns_record_data":  dnsRecordMapSchema("data", domainAuthenticationDNSRoles),
 			"dns": {
 				Type:        schema.TypeList,
 				Description: "The DNS records used to authenticate the sending domain.",
@@ -170,6 +189,8 @@ func resourceSendgridDomainAuthenticationCreate(
 
 	d.SetId(fmt.Sprint(auth.ID))
 
+	// The DNS records can't be published before this resource exists, as they depend on it:
+	// they are validated on the next apply, once the valid attribute is planned.
 	return resourceSendgridDomainAuthenticationRead(ctx, d, m)
 }
 
@@ -269,6 +290,23 @@ func resourceSendgridDomainAuthenticationRead( //nolint:funlen,cyclop
 		return diag.FromErr(er)
 	}
 
+	records := []dnsRecord{
+		{role: "mail_cname", value: sendgrid.DNSRecordValue(auth.DNS.MailCNAME)},
+		{role: "dkim1", value: sendgrid.DNSRecordValue(auth.DNS.DKIM1)},
+		{role: "dkim2", value: sendgrid.DNSRecordValue(auth.DNS.DKIM2)},
+		{role: "mail_server", value: sendgrid.DNSRecordValue(auth.DNS.MailServer)},
+		{role: "subdomain_spf", value: sendgrid.DNSRecordValue(auth.DNS.SubDomainSPF)},
+		{role: "dkim", value: sendgrid.DNSRecordValue(auth.DNS.DKIM)},
+	}
+
+	if er := d.Set("dns_records", flattenDNSRecords(records)); er != nil {
+		return diag.FromErr(er)
+	}
+
+	if er := setDNSRecordMaps(d, records); er != nil {
+		return diag.FromErr(er)
+	}
+
 	return nil
 }
 
@@ -291,12 +329,12 @@ func resourceSendgridDomainAuthenticationUpdate(
 	}
 
 	if !auth.(*sendgrid.DomainAuthentication).Valid && d.Get("valid").(bool) {
-		if err := c.ValidateDomainAuthentication(ctx, d.Id()); err.Err != nil || err.StatusCode != 200 {
-			if err.Err != nil {
-				return diag.FromErr(err.Err)
-			}
+		validate := func() (*sendgrid.DNSValidation, sendgrid.RequestError) {
+			return c.ValidateDomainAuthentication(ctx, d.Id())
+		}
 
-			return diag.Errorf("unable to validate domain DNS configuration")
+		if diags := validateDNSRecords(ctx, d, "domain authentication", validate); diags.HasError() {
+			return diags
 		}
 	}
 
@@ -320,3 +358,305 @@ func resourceSendgridDomainAuthenticationDelete(
 
 	return nil
 }
+
+// dnsRecord is a DNS record identified by its role in the authentication.
+type dnsRecord struct {
+	role  string
+	value sendgrid.DNSRecordValue
+}
+
+func dnsRecordsSchema(description string) *schema.Schema {
+	fields := dnsRecordFields()
+	fields["role"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The role of the record, stable across refreshes.",
+		Computed:    true,
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+// domainAuthenticationDNSRoles lists the roles of the records of an authenticated domain.
+const domainAuthenticationDNSRoles = "`mail_cname`, `dkim1` and `dkim2` with automatic security, " +
+	"`mail_server`, `subdomain_spf` and `dkim` with manual security"
+
+// dnsRecordMapSchema holds a field of the records SendGrid returned, keyed by their role.
+func dnsRecordMapSchema(field, roles string) *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeMap,
+		Description: fmt.Sprintf("The `%s` of the records of `dns_records`, keyed by their role: %s. ", field, roles) +
+			"A role is missing when SendGrid doesn't use its record.",
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func dnsRecordFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Description: "The type of DNS record, in upper case.",
+			Computed:    true,
+		},
+		"host": {
+			Type:        schema.TypeString,
+			Description: "The name of the DNS record.",
+			Computed:    true,
+		},
+		"data": {
+			Type:        schema.TypeString,
+			Description: "The value of the DNS record.",
+			Computed:    true,
+		},
+		"valid": {
+			Type:        schema.TypeBool,
+			Description: "Indicates if SendGrid found the record at the last validation.",
+			Computed:    true,
+		},
+	}
+}
+
+// flattenDNSRecords keeps the records SendGrid returned, in the given order.
+func flattenDNSRecords(records []dnsRecord) []interface{} {
+	flattened := make([]interface{}, 0, len(records))
+
+	for _, record := range records {
+		if record.value.Type == "" {
+			continue
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"role":  record.role,
+			"type":  strings.ToUpper(record.value.Type),
+			"host":  record.value.Host,
+			"data":  record.value.Data,
+			"valid": record.value.Valid,
+		})
+	}
+
+	return flattened
+}
+
+// flattenDNSRecordMap keys a field of the records SendGrid returned by their role.
+func flattenDNSRecordMap(records []dnsRecord, field string) map[string]interface{} {
+	byRole := map[string]interface{}{}
+
+	for _, record := range flattenDNSRecords(records) {
+		fields := record.(map[string]interface{})
+		byRole[fields["role"].(string)] = fields[field]
+	}
+
+	return byRole
+}
+
+// setDNSRecordMaps sets the host, the type and the data of the records, keyed by their role.
+func setDNSRecordMaps(d *schema.ResourceData, records []dnsRecord) error {
+	for attribute, field := range map[string]string{
+		"dns_record_hosts": "host",
+		"dns_record_types": "type",
+		"dns_record_data":  "data",
+	} {
+		if err := d.Set(attribute, flattenDNSRecordMap(records, field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func waitForValidationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Description: "Poll the validate endpoint until all the DNS records are valid. " +
+			"The records can't be valid on creation, as they are published after it: " +
+			"they are validated on the next apply, and again on each apply until they are valid.",
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timeout": {
+					Type:             schema.TypeString,
+					Description:      "How long to wait for the DNS records to be valid, e.g. `10m`.",
+					Optional:         true,
+					Default:          "10m",
+					ValidateDiagFunc: validateDuration,
+				},
+				"interval": {
+					Type:             schema.TypeString,
+					Description:      "How long to wait between two validation attempts, e.g. `30s`.",
+					Optional:         true,
+					Default:          "30s",
+					ValidateDiagFunc: validateDuration,
+				},
+			},
+		},
+	}
+}
+
+func validateDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	return checkDuration(v, path, false)
+}
+
+// validateNonNegativeDuration is validateDuration, allowing a zero duration.
+func validateNonNegativeDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	return checkDuration(v, path, true)
+}
+
+func checkDuration(v interface{}, path cty.Path, allowZero bool) diag.Diagnostics {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	if duration < 0 || (duration == 0 && !allowZero) {
+		detail := "The duration must be positive."
+		if allowZero {
+			detail = "The duration must not be negative."
+		}
+
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        detail,
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// resourceSendgridDNSValidationCustomizeDiff plans a validation when wait_for_validation is set
+// and the DNS records were not valid yet at the last refresh.
+func resourceSendgridDNSValidationCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || len(diff.Get("wait_for_validation").([]interface{})) == 0 {
+		return nil
+	}
+
+	if !diff.Get("valid").(bool) {
+		return diff.SetNew("valid", true)
+	}
+
+	return nil
+}
+
+// validateDNSRecords validates the DNS records once, or until they are valid when wait_for_validation is set.
+func validateDNSRecords(
+	ctx context.Context,
+	d *schema.ResourceData,
+	kind string,
+	validate func() (*sendgrid.DNSValidation, sendgrid.RequestError),
+) diag.Diagnostics {
+	if len(d.Get("wait_for_validation").([]interface{})) > 0 {
+		return waitForDNSValidation(ctx, d, kind, validate)
+	}
+
+	if _, err := validate(); err.Err != nil {
+		return diag.FromErr(err.Err)
+	}
+
+	return nil
+}
+
+// waitForDNSValidation polls the validate endpoint until all the DNS records are valid,
+// and reports the records still failing as errors once the timeout is reached.
+func waitForDNSValidation(
+	ctx context.Context,
+	d *schema.ResourceData,
+	kind string,
+	validate func() (*sendgrid.DNSValidation, sendgrid.RequestError),
+) diag.Diagnostics {
+	block := d.Get("wait_for_validation").([]interface{})
+	if len(block) == 0 || block[0] == nil {
+		return nil
+	}
+
+	settings := block[0].(map[string]interface{})
+	timeout, _ := time.ParseDuration(settings["timeout"].(string))
+	interval, _ := time.ParseDuration(settings["interval"].(string))
+	deadline := time.Now().Add(timeout)
+
+	var last *sendgrid.DNSValidation
+
+	for {
+		result, err := validate()
+		if err.Err != nil && err.StatusCode != http.StatusTooManyRequests {
+			return diag.FromErr(err.Err)
+		}
+
+		if err.Err == nil {
+			if result.Valid {
+				return nil
+			}
+
+			last = result
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return dnsValidationDiagnostics(kind, timeout, last)
+		}
+
+		select {
+		case <-ctx.Done():
+			return diag.FromErr(ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+func dnsValidationDiagnostics(
+	kind string,
+	timeout time.Duration,
+	result *sendgrid.DNSValidation,
+) diag.Diagnostics {
+	diags := diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("The %s DNS records are still not valid after %s", kind, timeout),
+		Detail: "Check that the records listed in the `dns` attribute are published. " +
+			"DNS changes can take up to 48 hours to propagate.",
+	}}
+
+	if result == nil {
+		return diags
+	}
+
+	records := make([]string, 0, len(result.ValidationResults))
+	for record := range result.ValidationResults {
+		records = append(records, record)
+	}
+
+	sort.Strings(records)
+
+	for _, record := range records {
+		validation := result.ValidationResults[record]
+		if validation.Valid {
+			continue
+		}
+
+		reason := validation.Reason
+		if reason == "" {
+			reason = "SendGrid did not report a reason."
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The %s DNS record is not valid", record),
+			Detail:   reason,
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,192 @@
+/*
+Provide a resource to share an authenticated domain of the parent account with a subuser.
+Example Usage
+```hcl
+
+	resource "sendgrid_domain_authentication_subuser" "default" {
+		domain_authentication_id = sendgrid_domain_authentication.default.id
+		username                 = sendgrid_subuser.customer.username
+	}
+
+```
+Import
+A domain authentication association can be imported, e.g.
+```hcl
+$ terraform import sendgrid_domain_authentication_subuser.default domainId/username
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSendgridDomainAuthenticationSubuser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSendgridDomainAuthenticationSubuserCreate,
+		ReadContext:   resourceSendgridDomainAuthenticationSubuserRead,
+		DeleteContext: resourceSendgridDomainAuthenticationSubuserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSendgridDomainAuthenticationSubuserImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_authentication_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the authenticated domain of the parent account.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "Username of the subuser the authenticated domain is shared with.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"multiple": {
+				Type: schema.TypeBool,
+				Description: "Use the association endpoints allowing a subuser to have up to five authenticated domains. " +
+					"Otherwise a subuser can only be associated with one authenticated domain.",
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "The authenticated domain shared with the subuser.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSendgridDomainAuthenticationSubuserCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	id := d.Get("domain_authentication_id").(string)
+	username := d.Get("username").(string)
+	multiple := d.Get("multiple").(bool)
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return nil, c.AssociateDomainAuthenticationWithSubuser(ctx, id, username, multiple)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id + "/" + username)
+
+	return resourceSendgridDomainAuthenticationSubuserRead(ctx, d, m)
+}
+
+func resourceSendgridDomainAuthenticationSubuserRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	id := d.Get("domain_authentication_id").(string)
+	username := d.Get("username").(string)
+
+	auths, err := c.ReadSubuserDomainAuthentications(ctx, username, d.Get("multiple").(bool))
+	if err.Err != nil {
+		return diag.FromErr(err.Err)
+	}
+
+	for _, auth := range auths {
+		if fmt.Sprint(auth.ID) == id {
+			//nolint:errcheck
+			d.Set("domain", auth.Domain)
+
+			return nil
+		}
+	}
+
+	// The association was removed outside of Terraform.
+	d.SetId("")
+
+	return nil
+}
+
+func resourceSendgridDomainAuthenticationSubuserDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	id := d.Get("domain_authentication_id").(string)
+	username := d.Get("username").(string)
+	multiple := d.Get("multiple").(bool)
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.DisassociateDomainAuthenticationFromSubuser(ctx, id, username, multiple)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSendgridDomainAuthenticationSubuserImport(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) ([]*schema.ResourceData, error) {
+	id, username, err := parseSubuserAssociationID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	d.Set("domain_authentication_id", id)
+	//nolint:errcheck
+	d.Set("username", username)
+
+	// Associations made through the multiple endpoint are only listed by it.
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	auths, requestErr := c.ReadSubuserDomainAuthentications(ctx, username, false)
+	if requestErr.Err != nil {
+		return nil, requestErr.Err
+	}
+
+	multiple := len(auths) == 0 || fmt.Sprint(auths[0].ID) != id
+
+	//nolint:errcheck
+	d.Set("multiple", multiple)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseSubuserAssociationID splits the ID of an association between a parent account resource
+// and a subuser, formatted as {{id}}/{{username}}.
+func parseSubuserAssociationID(importID string) (string, string, error) {
+	parts := strings.SplitN(importID, "/", ImportSplitParts)
+	if len(parts) != ImportSplitParts || parts[0] == "" || parts[1] == "" {
+		return "", "", ErrInvalidSubuserAssociationImportFormat
+	}
+
+	if _, err := strconv.Atoi(parts[0]); err != nil {
+		return "", "", ErrInvalidSubuserAssociationImportFormat
+	}
+
+	return parts[0], parts[1], nil
+}
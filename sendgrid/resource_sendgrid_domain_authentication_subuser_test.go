@@ -0,0 +1,84 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridDomainAuthenticationSubuserBasic(t *testing.T) {
+	domain := "auth-" + acctest.RandString(10) + ".example.com"
+	username := "terraform-subuser-" + acctest.RandString(10)
+	email := username + "@example.com"
+	password := "Passw0rd!" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridDomainAuthenticationSubuserConfigBasic(domain, username, email, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"sendgrid_domain_authentication_subuser.test", "username", username),
+					resource.TestCheckResourceAttr(
+						"sendgrid_domain_authentication_subuser.test", "domain", domain),
+					resource.TestCheckResourceAttr(
+						"sendgrid_domain_authentication_subuser.test", "multiple", "false"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_domain_authentication_subuser.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSendgridDomainAuthenticationSubuserInvalidImport(t *testing.T) {
+	domain := "auth-" + acctest.RandString(10) + ".example.com"
+	username := "terraform-subuser-" + acctest.RandString(10)
+	email := username + "@example.com"
+	password := "Passw0rd!" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridDomainAuthenticationSubuserConfigBasic(domain, username, email, password),
+			},
+			{
+				ResourceName:  "sendgrid_domain_authentication_subuser.test",
+				ImportState:   true,
+				ImportStateId: username,
+				ExpectError:   regexp.MustCompile("Supported import format"),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridDomainAuthenticationSubuserConfigBasic(domain, username, email, password string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_domain_authentication" "test" {
+	domain             = "%s"
+	is_default         = false
+	automatic_security = true
+}
+
+resource "sendgrid_subuser" "test" {
+	username = "%s"
+	email    = "%s"
+	password = "%s"
+}
+
+resource "sendgrid_domain_authentication_subuser" "test" {
+	domain_authentication_id = sendgrid_domain_authentication.test.id
+	username                 = sendgrid_subuser.test.username
+}
+`, domain, username, email, password)
+}
@@ -6,6 +6,10 @@ Example Usage
 	resource "sendgrid_link_branding" "default" {
 		domain = "example.com"
 	    is_default = true
+
+	    wait_for_validation {
+	        timeout = "15m"
+	    }
 	}
 
 ```
@@ -26,6 +30,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// linkBrandingDNSRoles lists the roles of the records of a link branding.
+const linkBrandingDNSRoles = "`domain_cname` and `owner_cname`"
+
 func resourceSendgridLinkBranding() *schema.Resource { //nolint:funlen
 	return &schema.Resource{
 		CreateContext: resourceSendgridLinkBrandingCreate,
@@ -35,6 +42,7 @@ func resourceSendgridLinkBranding() *schema.Resource { //nolint:funlen
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceSendgridDNSValidationCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"domain": {
@@ -67,6 +75,14 @@ func resourceSendgridLinkBranding() *schema.Resource { //nolint:funlen
 				Optional: true,
 				Computed: true,
 			},
+			"wait_for_validation": waitForValidationSchema(),
+			"dns_records": dnsRecordsSchema(
+				"The DNS records used to brand the links, in a stable order and identified by `role`: " +
+					linkBrandingDNSRoles + ".",
+			),
+			"dns_record_hosts": dnsRecordMapSchema("host", linkBrandingDNSRoles),
+			"dns_record_types": dnsRecordMapSchema("type", linkBrandingDNSRoles),
+			"dns_record_data":  dnsRecordMapSchema("data", linkBrandingDNSRoles),
 			"dns": {
 				Type:        schema.TypeList,
 				Description: "The DNS records used to authenticate the sending domain.",
@@ -120,6 +136,8 @@ func resourceSendgridLinkBrandingCreate(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(fmt.Sprint(link.ID))
 
+	// The DNS records can't be published before this resource exists, as they depend on it:
+	// they are validated on the next apply, once the valid attribute is planned.
 	return resourceSendgridLinkBrandingRead(ctx, d, m)
 }
 
@@ -166,6 +184,19 @@ func resourceSendgridLinkBrandingRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(er)
 	}
 
+	records := []dnsRecord{
+		{role: "domain_cname", value: sendgrid.DNSRecordValue(link.DNS.DomainCNAME)},
+		{role: "owner_cname", value: sendgrid.DNSRecordValue(link.DNS.OwnerCNAME)},
+	}
+
+	if er := d.Set("dns_records", flattenDNSRecords(records)); er != nil {
+		return diag.FromErr(er)
+	}
+
+	if er := setDNSRecordMaps(d, records); er != nil {
+		return diag.FromErr(er)
+	}
+
 	return nil
 }
 
@@ -183,12 +214,12 @@ func resourceSendgridLinkBrandingUpdate(ctx context.Context, d *schema.ResourceD
 	}
 
 	if !link.(*sendgrid.LinkBranding).Valid && d.Get("valid").(bool) {
-		if err := c.ValidateLinkBranding(ctx, d.Id()); err.Err != nil || err.StatusCode != 200 {
-			if err.Err != nil {
-				return diag.FromErr(err.Err)
-			}
+		validate := func() (*sendgrid.DNSValidation, sendgrid.RequestError) {
+			return c.ValidateLinkBranding(ctx, d.Id())
+		}
 
-			return diag.Errorf("unable to validate link branding DNS configuration")
+		if diags := validateDNSRecords(ctx, d, "link branding", validate); diags.HasError() {
+			return diags
 		}
 	}
 
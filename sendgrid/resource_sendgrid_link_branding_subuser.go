@@ -0,0 +1,131 @@
+/*
+Provide a resource to share a branded link of the parent account with a subuser.
+Example Usage
+```hcl
+
+	resource "sendgrid_link_branding_subuser" "default" {
+		link_branding_id = sendgrid_link_branding.default.id
+		username         = sendgrid_subuser.customer.username
+	}
+
+```
+Import
+A link branding association can be imported, e.g.
+```hcl
+$ terraform import sendgrid_link_branding_subuser.default linkId/username
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSendgridLinkBrandingSubuser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSendgridLinkBrandingSubuserCreate,
+		ReadContext:   resourceSendgridLinkBrandingSubuserRead,
+		DeleteContext: resourceSendgridLinkBrandingSubuserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSendgridLinkBrandingSubuserImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"link_branding_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the branded link of the parent account.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "Username of the subuser the branded link is shared with. A subuser can only have one.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "The branded link domain shared with the subuser.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSendgridLinkBrandingSubuserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	id := d.Get("link_branding_id").(string)
+	username := d.Get("username").(string)
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return nil, c.AssociateLinkBrandingWithSubuser(ctx, id, username)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id + "/" + username)
+
+	return resourceSendgridLinkBrandingSubuserRead(ctx, d, m)
+}
+
+func resourceSendgridLinkBrandingSubuserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	link, err := c.ReadSubuserLinkBranding(ctx, d.Get("username").(string))
+	if err.Err != nil {
+		return diag.FromErr(err.Err)
+	}
+
+	if link == nil || fmt.Sprint(link.ID) != d.Get("link_branding_id").(string) {
+		// The association was removed outside of Terraform.
+		d.SetId("")
+
+		return nil
+	}
+
+	//nolint:errcheck
+	d.Set("domain", link.Domain)
+
+	return nil
+}
+
+func resourceSendgridLinkBrandingSubuserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.DisassociateLinkBrandingFromSubuser(ctx, d.Get("username").(string))
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSendgridLinkBrandingSubuserImport(
+	_ context.Context,
+	d *schema.ResourceData,
+	_ interface{},
+) ([]*schema.ResourceData, error) {
+	id, username, err := parseSubuserAssociationID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	d.Set("link_branding_id", id)
+	//nolint:errcheck
+	d.Set("username", username)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,55 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridLinkBrandingSubuserBasic(t *testing.T) {
+	domain := "links-" + acctest.RandString(10) + ".example.com"
+	username := "terraform-subuser-" + acctest.RandString(10)
+	email := username + "@example.com"
+	password := "Passw0rd!" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridLinkBrandingSubuserConfigBasic(domain, username, email, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_link_branding_subuser.test", "username", username),
+					resource.TestCheckResourceAttr("sendgrid_link_branding_subuser.test", "domain", domain),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_link_branding_subuser.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridLinkBrandingSubuserConfigBasic(domain, username, email, password string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_link_branding" "test" {
+	domain     = "%s"
+	is_default = false
+}
+
+resource "sendgrid_subuser" "test" {
+	username = "%s"
+	email    = "%s"
+	password = "%s"
+}
+
+resource "sendgrid_link_branding_subuser" "test" {
+	link_branding_id = sendgrid_link_branding.test.id
+	username         = sendgrid_subuser.test.username
+}
+`, domain, username, email, password)
+}
@@ -0,0 +1,428 @@
+/*
+Provide a resource to provision SSO teammates from the users of an identity provider.
+Example Usage
+```hcl
+
+	resource "sendgrid_sso_teammate_mapping" "okta" {
+		users_file = "${path.module}/okta_users.csv"
+
+		group_rule {
+			group         = "sendgrid-developers"
+			scope_presets = ["read_only"]
+			scopes        = ["mail.send"]
+		}
+	}
+
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSendgridSSOTeammateMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provisions SSO teammates from the users of an identity provider, e.g. exported from Okta, " +
+			"and rules granting scopes to the members of their groups. " +
+			"The users matching no rule, or missing from the export, are removed at the next apply. " +
+			"The teammates are computed at plan time, so the plan shows the ones which are invited, updated and removed. " +
+			"Like sendgrid_teammates, only the teammates of the resource are managed.",
+
+		CreateContext: resourceSendgridTeammatesCreate,
+		ReadContext:   resourceSendgridTeammatesRead,
+		UpdateContext: resourceSendgridTeammatesUpdate,
+		DeleteContext: resourceSendgridTeammatesDelete,
+		CustomizeDiff: resourceSendgridSSOTeammateMappingCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"users": {
+				Type: schema.TypeString,
+				Description: "The users of the identity provider, in the format of `format`, " +
+					"e.g. `jsonencode(var.idp_users)`.",
+				Optional:     true,
+				ExactlyOneOf: []string{"users", "users_file"},
+			},
+			"users_file": {
+				Type: schema.TypeString,
+				Description: "The path of a file with the users of the identity provider, in the format of `format`. " +
+					"It's read at plan time.",
+				Optional:     true,
+				ExactlyOneOf: []string{"users", "users_file"},
+			},
+			"format": {
+				Type: schema.TypeString,
+				Description: "The format of the users: `json`, a list of objects with `email`, `first_name`, `last_name` and `groups`, " +
+					"or `csv`, with a header naming the same columns, the groups being separated by semicolons. " +
+					"Defaults to `csv` for the `users_file` with the .csv extension, and to `json` otherwise.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"json", "csv"}, false),
+			},
+			"group_rule": {
+				Type:        schema.TypeList,
+				Description: "The rules granting access to the members of a group. A user gets the scopes of all its groups' rules.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:        schema.TypeString,
+							Description: "The name of the group in the identity provider.",
+							Required:    true,
+						},
+						"is_admin": {
+							Type:        schema.TypeBool,
+							Description: "Whether the members of the group are admin teammates, with all the scopes.",
+							Optional:    true,
+							Default:     false,
+						},
+						"scopes": {
+							Type:        schema.TypeSet,
+							Description: "The scopes granted to the members of the group.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"scope_presets":  scopePresetsSchema(),
+						"scope_patterns": scopePatternsSchema(),
+					},
+				},
+			},
+			"allow_remove_all": {
+				Type: schema.TypeBool,
+				Description: "Whether an export without any user is accepted, removing all the teammates of the resource. " +
+					"The plan fails otherwise, since an empty or truncated export is more likely a mistake.",
+				Optional: true,
+				Default:  false,
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Description:  "How many requests are made concurrently when reading, inviting, updating and removing the teammates.",
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 20),
+			},
+			"teammate": {
+				Type:        schema.TypeSet,
+				Description: "The SSO teammates matching the rules, computed at plan time.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_admin": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_sso": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"first_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scopes": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeMap,
+				Description: "The status of each teammate by email, SSO teammates are 'active' from their creation.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"usernames": {
+				Type:        schema.TypeMap,
+				Description: "The username of each teammate by email.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// idpUser is a user exported from an identity provider.
+type idpUser struct {
+	Email     string   `json:"email"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name"`
+	Groups    []string `json:"groups"`
+}
+
+// ssoGroupRule grants access to the members of a group, its scopes include the ones of its presets and patterns.
+type ssoGroupRule struct {
+	Group   string
+	IsAdmin bool
+	Scopes  []string
+}
+
+// parseIdPUsers parses the users of an identity provider, in the json or csv format.
+func parseIdPUsers(content, format string) ([]idpUser, error) {
+	var users []idpUser
+
+	switch format {
+	case "csv":
+		records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the users: %w", err)
+		}
+
+		if len(records) == 0 {
+			return nil, nil
+		}
+
+		columns := map[string]int{}
+		for i, column := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(column))] = i
+		}
+
+		if _, ok := columns["email"]; !ok {
+			return nil, ErrSSOMappingCSVHeader
+		}
+
+		field := func(record []string, column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		for _, record := range records[1:] {
+			user := idpUser{
+				Email:     field(record, "email"),
+				FirstName: field(record, "first_name"),
+				LastName:  field(record, "last_name"),
+			}
+
+			for _, group := range strings.Split(field(record, "groups"), ";") {
+				if group = strings.TrimSpace(group); group != "" {
+					user.Groups = append(user.Groups, group)
+				}
+			}
+
+			users = append(users, user)
+		}
+	default:
+		if err := json.Unmarshal([]byte(content), &users); err != nil {
+			return nil, fmt.Errorf("could not parse the users: %w", err)
+		}
+	}
+
+	seen := map[string]bool{}
+
+	for _, user := range users {
+		if user.Email == "" {
+			return nil, ErrSSOMappingUserEmail
+		}
+
+		if seen[user.Email] {
+			return nil, fmt.Errorf("%w: %s", ErrSSOMappingDuplicateUser, user.Email)
+		}
+
+		seen[user.Email] = true
+	}
+
+	return users, nil
+}
+
+// readIdPUsers reads the users of the users argument, or of the users_file one.
+func readIdPUsers(get func(string) interface{}) ([]idpUser, error) {
+	content := get("users").(string)
+	format := get("format").(string)
+
+	if path := get("users_file").(string); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read users_file: %w", err)
+		}
+
+		content = string(raw)
+
+		if format == "" && strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = "csv"
+		}
+	}
+
+	return parseIdPUsers(content, format)
+}
+
+// expandSSOGroupRules expands the scopes of the rules, and validates them.
+func expandSSOGroupRules(v interface{}, catalog *scopeCatalog) ([]ssoGroupRule, error) {
+	var (
+		rules []ssoGroupRule
+		errs  []error
+	)
+
+	for _, raw := range v.([]interface{}) {
+		groupRule := raw.(map[string]interface{})
+		rule := ssoGroupRule{
+			Group:   groupRule["group"].(string),
+			IsAdmin: groupRule["is_admin"].(bool),
+			Scopes:  scopesFromSet(groupRule["scopes"].(*schema.Set)),
+		}
+
+		presets := scopesFromSet(groupRule["scope_presets"].(*schema.Set))
+		patterns := scopesFromSet(groupRule["scope_patterns"].(*schema.Set))
+
+		if rule.IsAdmin && len(rule.Scopes)+len(presets)+len(patterns) > 0 {
+			errs = append(errs, fmt.Errorf("group %s: %w", rule.Group, ErrTeammateAdminScopes))
+
+			continue
+		}
+
+		expanded, err := catalog.expand(presets, patterns, sendgridAutomaticScopes)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("group %s: %w", rule.Group, err))
+
+			continue
+		}
+
+		if err := catalog.check(rule.Scopes, sendgridAutomaticScopes).err(); err != nil {
+			errs = append(errs, fmt.Errorf("group %s: %w", rule.Group, err))
+
+			continue
+		}
+
+		rule.Scopes = append(rule.Scopes, expanded...)
+		rules = append(rules, rule)
+	}
+
+	return rules, errors.Join(errs...)
+}
+
+// mapIdPUsers returns the SSO teammates of the users matching at least one rule, by email.
+// A teammate is an admin when one of its rules is, and has the scopes of all its rules otherwise.
+func mapIdPUsers(users []idpUser, rules []ssoGroupRule) (map[string]teammateSpec, error) {
+	specs := map[string]teammateSpec{}
+
+	var errs []error
+
+	for _, user := range users {
+		groups := map[string]bool{}
+		for _, group := range user.Groups {
+			groups[group] = true
+		}
+
+		matched := false
+		isAdmin := false
+		scopes := map[string]bool{}
+
+		for _, rule := range rules {
+			if !groups[rule.Group] {
+				continue
+			}
+
+			matched = true
+			isAdmin = isAdmin || rule.IsAdmin
+
+			for _, scope := range rule.Scopes {
+				scopes[scope] = true
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		if user.FirstName == "" || user.LastName == "" {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrSSOMappingUserNames, user.Email))
+
+			continue
+		}
+
+		spec := teammateSpec{
+			Email:     user.Email,
+			IsAdmin:   isAdmin,
+			IsSSO:     true,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+		}
+
+		if !isAdmin {
+			for scope := range scopes {
+				spec.Scopes = append(spec.Scopes, scope)
+			}
+
+			sort.Strings(spec.Scopes)
+		}
+
+		specs[spec.Email] = spec
+	}
+
+	return specs, errors.Join(errs...)
+}
+
+// resourceSendgridSSOTeammateMappingCustomizeDiff computes the teammates from the users and the rules,
+// so that the plan shows the teammates which change, including when only the file of the users changed.
+func resourceSendgridSSOTeammateMappingCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"users", "users_file", "format", "group_rule"} {
+		if !diff.NewValueKnown(key) {
+			return diff.SetNewComputed("teammate")
+		}
+	}
+
+	users, err := readIdPUsers(diff.Get)
+	if err != nil {
+		return err
+	}
+
+	rules, err := expandSSOGroupRules(diff.Get("group_rule"), meta.(*Config).scopeCatalog(ctx, ""))
+	if err != nil {
+		return err
+	}
+
+	// An empty or truncated export would remove all the teammates.
+	if len(users) == 0 && !diff.Get("allow_remove_all").(bool) {
+		return ErrSSOMappingNoUsers
+	}
+
+	desired, err := mapIdPUsers(users, rules)
+	if err != nil {
+		return err
+	}
+
+	current := expandTeammateSpecs(diff.Get("teammate"))
+
+	plan := planTeammates(current, desired)
+	if len(plan.invite)+len(plan.update)+len(plan.remove) == 0 {
+		return nil
+	}
+
+	return diff.SetNew("teammate", flattenTeammateSpecs(desired))
+}
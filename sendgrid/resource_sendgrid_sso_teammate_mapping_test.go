@@ -0,0 +1,54 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridSSOTeammateMapping_basic(t *testing.T) {
+	prefix := "terraform-sso-mapping-" + acctest.RandString(10)
+	ada := prefix + "-ada@example.com"
+	grace := prefix + "-grace@example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridSSOTeammateMappingConfig(ada, grace, "developers"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate_mapping.test", "teammate.#", "2"),
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate_mapping.test", "status."+ada, "active"),
+				),
+			},
+			// grace leaves the developers group, and loses her access.
+			{
+				Config: testAccCheckSendgridSSOTeammateMappingConfig(ada, grace, "marketing"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate_mapping.test", "teammate.#", "1"),
+					resource.TestCheckNoResourceAttr("sendgrid_sso_teammate_mapping.test", "status."+grace),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridSSOTeammateMappingConfig(ada, grace, graceGroup string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_sso_teammate_mapping" "test" {
+	users = jsonencode([
+		{ email = %q, first_name = "Ada", last_name = "Lovelace", groups = ["developers"] },
+		{ email = %q, first_name = "Grace", last_name = "Hopper", groups = [%q] },
+	])
+
+	group_rule {
+		group         = "developers"
+		scope_presets = ["mail_send_only"]
+		scopes        = ["templates.read"]
+	}
+}
+`, ada, grace, graceGroup)
+}
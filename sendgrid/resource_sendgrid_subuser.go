@@ -10,6 +10,32 @@ Example Usage
 		ips      = [
 			"127.0.0.1"
 		]
+
+		credit_allocation {
+			type            = "recurring"
+			reset_frequency = "monthly"
+			total           = 10000
+		}
+
+		monitor {
+			email     = "monitor@example.org"
+			frequency = 500
+		}
+	}
+
+```
+The password can be kept out of the state with a write-only attribute (Terraform 1.11+).
+Bump password_version to rotate it. The current password is still required to do so: SendGrid has no API
+to set the password of a subuser without it, pass it with previous_password_wo.
+```hcl
+
+	resource "sendgrid_subuser" "subuser" {
+		username             = "my-subuser"
+		email                = "subuser@example.org"
+		password_wo          = var.subuser_password
+		previous_password_wo = var.subuser_previous_password
+		password_version     = 2
+		ips                  = ["127.0.0.1"]
 	}
 
 ```
@@ -23,13 +49,17 @@ package sendgrid
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func resourceSendgridSubuser() *schema.Resource {
+func resourceSendgridSubuser() *schema.Resource { //nolint:funlen
 	return &schema.Resource{
 		CreateContext: resourceSendgridSubuserCreate,
 		ReadContext:   resourceSendgridSubuserRead,
@@ -39,6 +69,12 @@ func resourceSendgridSubuser() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceSendgridSubuserCustomizeDiff,
+
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("password"), cty.GetAttrPath("password_wo")),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:        schema.TypeString,
@@ -46,10 +82,36 @@ func resourceSendgridSubuser() *schema.Resource {
 				Required:    true,
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Description: "The password the subuser will use when logging into SendGrid.",
-				Sensitive:   true,
-				Required:    true,
+				Type: schema.TypeString,
+				Description: "The password the subuser will use when logging into SendGrid. " +
+					"It is stored in the state, prefer `password_wo`.",
+				Sensitive:    true,
+				Optional:     true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type: schema.TypeString,
+				Description: "The password the subuser will use when logging into SendGrid. " +
+					"This value is write-only and never stored in the state, " +
+					"change `password_version` to apply a new value.",
+				Sensitive: true,
+				Optional:  true,
+				WriteOnly: true,
+			},
+			"previous_password_wo": {
+				Type: schema.TypeString,
+				Description: "The current password of the subuser, required to rotate `password_wo`: " +
+					"SendGrid has no API to set the password of a subuser without its current one. " +
+					"This value is write-only and never stored in the state.",
+				Sensitive: true,
+				Optional:  true,
+				WriteOnly: true,
+			},
+			"password_version": {
+				Type:         schema.TypeInt,
+				Description:  "Change this value to set the subuser password to the value of `password_wo`.",
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"email": {
 				Type:        schema.TypeString,
@@ -83,6 +145,253 @@ func resourceSendgridSubuser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"credit_allocation": {
+				Type: schema.TypeList,
+				Description: "The credits the subuser is allowed to use. Defaults to unlimited credits, " +
+					"which are restored when the block is removed.",
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Description:  "The type of credit allocation, allowed values: unlimited, recurring, nonrecurring.",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"unlimited", "recurring", "nonrecurring"}, false),
+						},
+						"reset_frequency": {
+							Type: schema.TypeString,
+							Description: "How often the credits are reset, allowed values: monthly, weekly, daily. " +
+								"Required for recurring credits.",
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"monthly", "weekly", "daily"}, false),
+						},
+						"total": {
+							Type: schema.TypeInt,
+							Description: "The number of credits allocated to the subuser. " +
+								"Required for recurring and nonrecurring credits.",
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"credits_remaining": {
+				Type:        schema.TypeInt,
+				Description: "The number of credits the subuser has left in the current period.",
+				Computed:    true,
+			},
+			"credits_used": {
+				Type:        schema.TypeInt,
+				Description: "The number of credits the subuser has used in the current period.",
+				Computed:    true,
+			},
+			"monitor": {
+				Type:        schema.TypeList,
+				Description: "Receive a sample of the emails sent by the subuser, to monitor its sending.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:        schema.TypeString,
+							Description: "The email address the sample emails are sent to.",
+							Required:    true,
+						},
+						"frequency": {
+							Type:         schema.TypeInt,
+							Description:  "A sample email is sent every `frequency` emails sent by the subuser.",
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"website_access_disabled": {
+				Type: schema.TypeBool,
+				Description: "Whether the subuser is denied access to the SendGrid website. " +
+					"The configured value is kept when the API doesn't report the website access of the subuser.",
+				Optional: true,
+				Default:  false,
+			},
+			"reputation": {
+				Type:        schema.TypeFloat,
+				Description: "The sender reputation of the subuser, between 0 and 100.",
+				Computed:    true,
+			},
+			"stats": {
+				Type:        schema.TypeList,
+				Description: "The email statistics of the subuser for the current month.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requests":      {Type: schema.TypeInt, Computed: true},
+						"delivered":     {Type: schema.TypeInt, Computed: true},
+						"bounces":       {Type: schema.TypeInt, Computed: true},
+						"blocks":        {Type: schema.TypeInt, Computed: true},
+						"opens":         {Type: schema.TypeInt, Computed: true},
+						"unique_opens":  {Type: schema.TypeInt, Computed: true},
+						"clicks":        {Type: schema.TypeInt, Computed: true},
+						"unique_clicks": {Type: schema.TypeInt, Computed: true},
+						"spam_reports":  {Type: schema.TypeInt, Computed: true},
+						"unsubscribes":  {Type: schema.TypeInt, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceSendgridSubuserCustomizeDiff checks that the credit allocation is consistent with its type,
+// SendGrid only reports these errors once the subuser is created.
+// credit_allocation is computed, so removing it from the configuration plans the reset to unlimited credits.
+func resourceSendgridSubuserCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() != "" && !subuserCreditsConfigured(diff) {
+		if expandSubuserCredits(diff.Get("credit_allocation").([]interface{})).Type != "unlimited" {
+			return diff.SetNew("credit_allocation", []interface{}{
+				map[string]interface{}{"type": "unlimited", "reset_frequency": "", "total": 0},
+			})
+		}
+
+		return nil
+	}
+
+	credits, ok := diff.GetOk("credit_allocation")
+	if !ok || len(credits.([]interface{})) == 0 || credits.([]interface{})[0] == nil {
+		return nil
+	}
+
+	allocation := expandSubuserCredits(credits.([]interface{}))
+
+	switch allocation.Type {
+	case "recurring":
+		if allocation.ResetFrequency == "" {
+			return fmt.Errorf("credit_allocation: reset_frequency is required for recurring credits")
+		}
+	case "nonrecurring":
+		if allocation.ResetFrequency != "" {
+			return fmt.Errorf("credit_allocation: reset_frequency can't be set for nonrecurring credits")
+		}
+	case "unlimited":
+		if allocation.ResetFrequency != "" || allocation.Total != 0 {
+			return fmt.Errorf("credit_allocation: reset_frequency and total can't be set for unlimited credits")
+		}
+	}
+
+	return nil
+}
+
+// subuserCreditsConfigured returns whether the credit_allocation block is in the configuration.
+// It's considered configured when the configuration isn't available, so that nothing is reset.
+func subuserCreditsConfigured(diff *schema.ResourceDiff) bool {
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return true
+	}
+
+	credits := config.GetAttr("credit_allocation")
+
+	return !credits.IsKnown() || (!credits.IsNull() && credits.LengthInt() > 0)
+}
+
+// subuserPassword returns the password of the subuser from the config,
+// either from the write-only attribute or from the legacy one stored in the state.
+func subuserPassword(d *schema.ResourceData) (string, error) {
+	return writeOnlyString(d, "password_wo", d.Get("password").(string))
+}
+
+// writeOnlyGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type writeOnlyGetter interface {
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// writeOnlyString returns the value of a write-only attribute, which is only available in the config,
+// or fallback when the attribute isn't set.
+func writeOnlyString(d writeOnlyGetter, key string, fallback string) (string, error) {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", fmt.Errorf("could not read write-only attribute %s", key)
+	}
+
+	if !value.Type().Equals(cty.String) || value.IsNull() || !value.IsKnown() {
+		return fallback, nil
+	}
+
+	return value.AsString(), nil
+}
+
+func expandSubuserCredits(credits []interface{}) sendgrid.SubUserCredits {
+	if len(credits) == 0 || credits[0] == nil {
+		return sendgrid.SubUserCredits{}
+	}
+
+	credit := credits[0].(map[string]interface{})
+
+	return sendgrid.SubUserCredits{
+		Type:           credit["type"].(string),
+		ResetFrequency: credit["reset_frequency"].(string),
+		Total:          credit["total"].(int),
+	}
+}
+
+func flattenSubuserCredits(credits *sendgrid.SubUserCredits) []interface{} {
+	if credits == nil || credits.Type == "" {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":            credits.Type,
+			"reset_frequency": credits.ResetFrequency,
+			"total":           credits.Total,
+		},
+	}
+}
+
+func expandSubuserMonitor(monitor []interface{}) *sendgrid.SubUserMonitor {
+	if len(monitor) == 0 || monitor[0] == nil {
+		return nil
+	}
+
+	m := monitor[0].(map[string]interface{})
+
+	return &sendgrid.SubUserMonitor{
+		Email:     m["email"].(string),
+		Frequency: m["frequency"].(int),
+	}
+}
+
+func flattenSubuserMonitor(monitor *sendgrid.SubUserMonitor) []interface{} {
+	if monitor == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"email":     monitor.Email,
+			"frequency": monitor.Frequency,
+		},
+	}
+}
+
+func flattenSubuserStats(stats *sendgrid.SubUserStatsMetrics) []interface{} {
+	if stats == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"requests":      stats.Requests,
+			"delivered":     stats.Delivered,
+			"bounces":       stats.Bounces,
+			"blocks":        stats.Blocks,
+			"opens":         stats.Opens,
+			"unique_opens":  stats.UniqueOpens,
+			"clicks":        stats.Clicks,
+			"unique_clicks": stats.UniqueClicks,
+			"spam_reports":  stats.SpamReports,
+			"unsubscribes":  stats.Unsubscribes,
 		},
 	}
 }
@@ -92,9 +401,13 @@ func resourceSendgridSubuserCreate(ctx context.Context, d *schema.ResourceData,
 	c := config.NewClient("")
 
 	username := d.Get("username").(string)
-	password := d.Get("password").(string)
 	email := d.Get("email").(string)
 
+	password, err := subuserPassword(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	ipsSet := d.Get("ips").(*schema.Set).List()
 	ips := make([]string, 0)
 
@@ -102,7 +415,7 @@ func resourceSendgridSubuserCreate(ctx context.Context, d *schema.ResourceData,
 		ips = append(ips, ip.(string))
 	}
 
-	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+	_, err = sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
 		return c.CreateSubuser(ctx, username, email, password, ips)
 	})
 	if err != nil {
@@ -111,21 +424,65 @@ func resourceSendgridSubuserCreate(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(username)
 
+	if err := resourceSendgridSubuserUpdateSettings(ctx, d, c); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.Get("disabled").(bool) {
-		return resourceSendgridSubuserUpdate(ctx, d, m)
+		if _, requestErr := c.UpdateSubuser(ctx, username, true); requestErr.Err != nil {
+			return diag.FromErr(requestErr.Err)
+		}
 	}
 
 	return resourceSendgridSubuserRead(ctx, d, m)
 }
 
+// resourceSendgridSubuserUpdateSettings applies the credit allocation, monitor and website access
+// of the subuser, which are managed through their own endpoints.
+func resourceSendgridSubuserUpdateSettings(ctx context.Context, d *schema.ResourceData, c *sendgrid.Client) error {
+	if credits, ok := d.GetOk("credit_allocation"); ok && d.HasChange("credit_allocation") {
+		allocation := expandSubuserCredits(credits.([]interface{}))
+
+		if _, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.UpdateSubuserCredits(ctx, d.Id(), allocation)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("monitor") {
+		o, n := d.GetChange("monitor")
+		oldMonitor := expandSubuserMonitor(o.([]interface{}))
+		newMonitor := expandSubuserMonitor(n.([]interface{}))
+
+		if _, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			if newMonitor == nil {
+				return nil, c.DeleteSubuserMonitor(ctx, d.Id())
+			}
+
+			return nil, c.CreateSubuserMonitor(ctx, d.Id(), *newMonitor, oldMonitor != nil)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("website_access_disabled") {
+		disabled := d.Get("website_access_disabled").(bool)
+
+		if _, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return nil, c.UpdateSubuserWebsiteAccess(ctx, d.Id(), disabled)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resourceSendgridSubuserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*Config)
 	c := config.NewClient("")
 
-	// hack to clear any on behalf of set in create sub user
-	// to fix this properly I think we need to pass this down rather than setting global state
-	c.OnBehalfOf = ""
-
 	subUser, requestErr := c.ReadSubUser(ctx, d.Id())
 	if requestErr.Err != nil {
 		return diag.FromErr(requestErr.Err)
@@ -142,6 +499,61 @@ func resourceSendgridSubuserRead(ctx context.Context, d *schema.ResourceData, m
 	//nolint:errcheck
 	d.Set("email", subUser[0].Email)
 
+	credits, requestErr := c.ReadSubuserCredits(ctx, d.Id())
+	if requestErr.Err != nil {
+		return diag.FromErr(requestErr.Err)
+	}
+
+	//nolint:errcheck
+	d.Set("credit_allocation_type", credits.Type)
+	//nolint:errcheck
+	d.Set("credits_remaining", credits.Remaining)
+	//nolint:errcheck
+	d.Set("credits_used", credits.Used)
+
+	if err := d.Set("credit_allocation", flattenSubuserCredits(credits)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	monitor, requestErr := c.ReadSubuserMonitor(ctx, d.Id())
+	if requestErr.Err != nil {
+		return diag.FromErr(requestErr.Err)
+	}
+
+	if err := d.Set("monitor", flattenSubuserMonitor(monitor)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	websiteAccessDisabled, requestErr := c.ReadSubuserWebsiteAccess(ctx, d.Id())
+	if requestErr.Err != nil {
+		return diag.FromErr(requestErr.Err)
+	}
+
+	// The configured value is kept when the API doesn't report the website access.
+	if websiteAccessDisabled != nil {
+		//nolint:errcheck
+		d.Set("website_access_disabled", *websiteAccessDisabled)
+	}
+
+	reputation, requestErr := c.ReadSubuserReputation(ctx, d.Id())
+	if requestErr.Err != nil {
+		return diag.FromErr(requestErr.Err)
+	}
+
+	//nolint:errcheck
+	d.Set("reputation", reputation.Reputation)
+
+	monthStart := time.Now().UTC().Format("2006-01") + "-01"
+
+	stats, requestErr := c.ReadSubuserMonthlyStats(ctx, d.Id(), monthStart)
+	if requestErr.Err != nil {
+		return diag.FromErr(requestErr.Err)
+	}
+
+	if err := d.Set("stats", flattenSubuserStats(stats)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -168,19 +580,44 @@ func resourceSendgridSubuserUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("password") {
+	// Moving the password to password_wo clears it from the state without changing it.
+	if d.HasChange("password") && d.Get("password").(string) != "" {
 		oldPassword, newPassword := d.GetChange("password")
-		username := d.Get("username").(string)
 
 		if requestErr := c.UpdateSubuserPassword(
 			ctx,
-			username,
+			d.Id(),
 			oldPassword.(string),
 			newPassword.(string)); requestErr.Err != nil {
 			return diag.FromErr(requestErr.Err)
 		}
 	}
 
+	if d.HasChange("password_version") {
+		newPassword, err := writeOnlyString(d, "password_wo", "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		oldPassword, err := writeOnlyString(d, "previous_password_wo", "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if oldPassword == "" {
+			return diag.Errorf("previous_password_wo must be set to rotate password_wo: " +
+				"SendGrid has no API to set the password of a subuser without its current one")
+		}
+
+		if requestErr := c.UpdateSubuserPassword(ctx, d.Id(), oldPassword, newPassword); requestErr.Err != nil {
+			return diag.FromErr(requestErr.Err)
+		}
+	}
+
+	if err := resourceSendgridSubuserUpdateSettings(ctx, d, c); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceSendgridSubuserRead(ctx, d, m)
 }
 
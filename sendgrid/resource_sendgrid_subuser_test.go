@@ -106,6 +106,77 @@ func TestAccSendgridSubuserWithRateLimiting(t *testing.T) {
 	})
 }
 
+func TestAccSendgridSubuserCreditsAndMonitor(t *testing.T) {
+	username := "terraform-subuser-credits-" + acctest.RandString(10)
+	email := username + "@example.com"
+	password := "TerraformTest123!"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridSubuserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridSubuserConfigCredits(username, email, password, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridSubuserExists("sendgrid_subuser.credits"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.credits", "credit_allocation.0.type", "recurring"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.credits", "credit_allocation.0.total", "1000"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.credits", "monitor.0.frequency", "500"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.credits", "website_access_disabled", "true"),
+					resource.TestCheckResourceAttrSet("sendgrid_subuser.credits", "credits_remaining"),
+				),
+			},
+			{
+				Config: testAccCheckSendgridSubuserConfigCredits(username, email, password, 2000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridSubuserExists("sendgrid_subuser.credits"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.credits", "credit_allocation.0.total", "2000"),
+				),
+			},
+			// Removing the credit allocation restores the unlimited credits.
+			{
+				Config: testAccCheckSendgridSubuserConfigCreditsRemoved(username, email, password),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridSubuserExists("sendgrid_subuser.credits"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.credits", "credit_allocation.0.type", "unlimited"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSendgridSubuserWriteOnlyPassword(t *testing.T) {
+	username := "terraform-subuser-wo-" + acctest.RandString(10)
+	email := username + "@example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridSubuserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridSubuserConfigWriteOnly(username, email, "TerraformTest123!", "", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridSubuserExists("sendgrid_subuser.wo"),
+					resource.TestCheckNoResourceAttr("sendgrid_subuser.wo", "password_wo"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.wo", "password", ""),
+					resource.TestCheckResourceAttr("sendgrid_subuser.wo", "password_version", "1"),
+				),
+			},
+			{
+				Config: testAccCheckSendgridSubuserConfigWriteOnly(
+					username, email, "TerraformTest456!", "TerraformTest123!", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridSubuserExists("sendgrid_subuser.wo"),
+					resource.TestCheckNoResourceAttr("sendgrid_subuser.wo", "previous_password_wo"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.wo", "password_version", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSendgridSubuserDestroy(s *terraform.State) error {
 	c := testAccProvider.Meta().(*sendgrid.Client)
 
@@ -177,6 +248,53 @@ resource "sendgrid_subuser" "rate_limit" {
 `, username, email, password)
 }
 
+func testAccCheckSendgridSubuserConfigCredits(username, email, password string, total int) string {
+	return fmt.Sprintf(`
+resource "sendgrid_subuser" "credits" {
+	username                = "%s"
+	email                   = "%s"
+	password                = "%s"
+	ips                     = ["192.168.1.1"]
+	website_access_disabled = true
+
+	credit_allocation {
+		type            = "recurring"
+		reset_frequency = "monthly"
+		total           = %d
+	}
+
+	monitor {
+		email     = "%s"
+		frequency = 500
+	}
+}
+`, username, email, password, total, email)
+}
+
+func testAccCheckSendgridSubuserConfigCreditsRemoved(username, email, password string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_subuser" "credits" {
+	username = "%s"
+	email    = "%s"
+	password = "%s"
+	ips      = ["192.168.1.1"]
+}
+`, username, email, password)
+}
+
+func testAccCheckSendgridSubuserConfigWriteOnly(username, email, password, previous string, version int) string {
+	return fmt.Sprintf(`
+resource "sendgrid_subuser" "wo" {
+	username             = "%s"
+	email                = "%s"
+	password_wo          = "%s"
+	previous_password_wo = "%s"
+	password_version     = %d
+	ips                  = ["192.168.1.1"]
+}
+`, username, email, password, previous, version)
+}
+
 func testAccCheckSendgridSubuserExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
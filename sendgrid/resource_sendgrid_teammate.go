@@ -17,290 +17,22 @@ package sendgrid
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// validSendgridScopes contains the actual list of valid SendGrid scopes
-// Retrieved from https://api.sendgrid.com/v3/scopes as of 2024
-var validSendgridScopes = map[string]bool{
-	"access_settings.activity.read":             true,
-	"access_settings.whitelist.create":          true,
-	"access_settings.whitelist.delete":          true,
-	"access_settings.whitelist.read":            true,
-	"access_settings.whitelist.update":          true,
-	"alerts.create":                             true,
-	"alerts.delete":                             true,
-	"alerts.read":                               true,
-	"alerts.update":                             true,
-	"api_keys.create":                           true,
-	"api_keys.delete":                           true,
-	"api_keys.read":                             true,
-	"api_keys.update":                           true,
-	"asm.groups.create":                         true,
-	"asm.groups.delete":                         true,
-	"asm.groups.read":                           true,
-	"asm.groups.suppressions.create":            true,
-	"asm.groups.suppressions.delete":            true,
-	"asm.groups.suppressions.read":              true,
-	"asm.groups.suppressions.update":            true,
-	"asm.groups.update":                         true,
-	"asm.suppressions.global.create":            true,
-	"asm.suppressions.global.delete":            true,
-	"asm.suppressions.global.read":              true,
-	"asm.suppressions.global.update":            true,
-	"billing.create":                            true,
-	"billing.delete":                            true,
-	"billing.read":                              true,
-	"billing.update":                            true,
-	"browsers.stats.read":                       true,
-	"categories.create":                         true,
-	"categories.delete":                         true,
-	"categories.read":                           true,
-	"categories.stats.read":                     true,
-	"categories.stats.sums.read":                true,
-	"categories.update":                         true,
-	"clients.desktop.stats.read":                true,
-	"clients.phone.stats.read":                  true,
-	"clients.stats.read":                        true,
-	"clients.tablet.stats.read":                 true,
-	"clients.webmail.stats.read":                true,
-	"credentials.create":                        true,
-	"credentials.delete":                        true,
-	"credentials.read":                          true,
-	"credentials.update":                        true,
-	"design_library.create":                     true,
-	"design_library.delete":                     true,
-	"design_library.read":                       true,
-	"design_library.update":                     true,
-	"devices.stats.read":                        true,
-	"di.bounce_block_classification.read":       true,
-	"email_testing.read":                        true,
-	"email_testing.write":                       true,
-	"geo.stats.read":                            true,
-	"ips.assigned.read":                         true,
-	"ips.create":                                true,
-	"ips.delete":                                true,
-	"ips.pools.create":                          true,
-	"ips.pools.delete":                          true,
-	"ips.pools.ips.create":                      true,
-	"ips.pools.ips.delete":                      true,
-	"ips.pools.ips.read":                        true,
-	"ips.pools.ips.update":                      true,
-	"ips.pools.read":                            true,
-	"ips.pools.update":                          true,
-	"ips.read":                                  true,
-	"ips.update":                                true,
-	"ips.warmup.create":                         true,
-	"ips.warmup.delete":                         true,
-	"ips.warmup.read":                           true,
-	"ips.warmup.update":                         true,
-	"mail.batch.create":                         true,
-	"mail.batch.delete":                         true,
-	"mail.batch.read":                           true,
-	"mail.batch.update":                         true,
-	"mail.send":                                 true,
-	"mail_settings.address_whitelist.create":    true,
-	"mail_settings.address_whitelist.delete":    true,
-	"mail_settings.address_whitelist.read":      true,
-	"mail_settings.address_whitelist.update":    true,
-	"mail_settings.bcc.create":                  true,
-	"mail_settings.bcc.delete":                  true,
-	"mail_settings.bcc.read":                    true,
-	"mail_settings.bcc.update":                  true,
-	"mail_settings.bounce_purge.create":         true,
-	"mail_settings.bounce_purge.delete":         true,
-	"mail_settings.bounce_purge.read":           true,
-	"mail_settings.bounce_purge.update":         true,
-	"mail_settings.footer.create":               true,
-	"mail_settings.footer.delete":               true,
-	"mail_settings.footer.read":                 true,
-	"mail_settings.footer.update":               true,
-	"mail_settings.forward_bounce.create":       true,
-	"mail_settings.forward_bounce.delete":       true,
-	"mail_settings.forward_bounce.read":         true,
-	"mail_settings.forward_bounce.update":       true,
-	"mail_settings.forward_spam.create":         true,
-	"mail_settings.forward_spam.delete":         true,
-	"mail_settings.forward_spam.read":           true,
-	"mail_settings.forward_spam.update":         true,
-	"mail_settings.plain_content.create":        true,
-	"mail_settings.plain_content.delete":        true,
-	"mail_settings.plain_content.read":          true,
-	"mail_settings.plain_content.update":        true,
-	"mail_settings.read":                        true,
-	"mail_settings.spam_check.create":           true,
-	"mail_settings.spam_check.delete":           true,
-	"mail_settings.spam_check.read":             true,
-	"mail_settings.spam_check.update":           true,
-	"mail_settings.template.create":             true,
-	"mail_settings.template.delete":             true,
-	"mail_settings.template.read":               true,
-	"mail_settings.template.update":             true,
-	"mailbox_providers.stats.read":              true,
-	"marketing.automation.read":                 true,
-	"marketing.read":                            true,
-	"messages.read":                             true,
-	"newsletter.create":                         true,
-	"newsletter.delete":                         true,
-	"newsletter.read":                           true,
-	"newsletter.update":                         true,
-	"partner_settings.new_relic.create":         true,
-	"partner_settings.new_relic.delete":         true,
-	"partner_settings.new_relic.read":           true,
-	"partner_settings.new_relic.update":         true,
-	"partner_settings.read":                     true,
-	"partner_settings.sendwithus.create":        true,
-	"partner_settings.sendwithus.delete":        true,
-	"partner_settings.sendwithus.read":          true,
-	"partner_settings.sendwithus.update":        true,
-	"recipients.erasejob.create":                true,
-	"recipients.erasejob.read":                  true,
-	"sender_verification_eligible":              true,
-	"signup.trigger_confirmation":               true,
-	"sso.settings.create":                       true,
-	"sso.settings.delete":                       true,
-	"sso.settings.read":                         true,
-	"sso.settings.update":                       true,
-	"sso.teammates.create":                      true,
-	"sso.teammates.update":                      true,
-	"stats.global.read":                         true,
-	"stats.read":                                true,
-	"subusers.create":                           true,
-	"subusers.credits.create":                   true,
-	"subusers.credits.delete":                   true,
-	"subusers.credits.read":                     true,
-	"subusers.credits.remaining.create":         true,
-	"subusers.credits.remaining.delete":         true,
-	"subusers.credits.remaining.read":           true,
-	"subusers.credits.remaining.update":         true,
-	"subusers.credits.update":                   true,
-	"subusers.delete":                           true,
-	"subusers.monitor.create":                   true,
-	"subusers.monitor.delete":                   true,
-	"subusers.monitor.read":                     true,
-	"subusers.monitor.update":                   true,
-	"subusers.read":                             true,
-	"subusers.reputations.read":                 true,
-	"subusers.stats.monthly.read":               true,
-	"subusers.stats.read":                       true,
-	"subusers.stats.sums.read":                  true,
-	"subusers.summary.read":                     true,
-	"subusers.update":                           true,
-	"suppression.blocks.create":                 true,
-	"suppression.blocks.delete":                 true,
-	"suppression.blocks.read":                   true,
-	"suppression.blocks.update":                 true,
-	"suppression.bounces.create":                true,
-	"suppression.bounces.delete":                true,
-	"suppression.bounces.read":                  true,
-	"suppression.bounces.update":                true,
-	"suppression.create":                        true,
-	"suppression.delete":                        true,
-	"suppression.invalid_emails.create":         true,
-	"suppression.invalid_emails.delete":         true,
-	"suppression.invalid_emails.read":           true,
-	"suppression.invalid_emails.update":         true,
-	"suppression.read":                          true,
-	"suppression.spam_reports.create":           true,
-	"suppression.spam_reports.delete":           true,
-	"suppression.spam_reports.read":             true,
-	"suppression.spam_reports.update":           true,
-	"suppression.unsubscribes.create":           true,
-	"suppression.unsubscribes.delete":           true,
-	"suppression.unsubscribes.read":             true,
-	"suppression.unsubscribes.update":           true,
-	"suppression.update":                        true,
-	"teammates.create":                          true,
-	"teammates.delete":                          true,
-	"teammates.read":                            true,
-	"teammates.update":                          true,
-	"templates.create":                          true,
-	"templates.delete":                          true,
-	"templates.read":                            true,
-	"templates.update":                          true,
-	"templates.versions.activate.create":        true,
-	"templates.versions.activate.delete":        true,
-	"templates.versions.activate.read":          true,
-	"templates.versions.activate.update":        true,
-	"templates.versions.create":                 true,
-	"templates.versions.delete":                 true,
-	"templates.versions.read":                   true,
-	"templates.versions.update":                 true,
-	"tracking_settings.click.create":            true,
-	"tracking_settings.click.delete":            true,
-	"tracking_settings.click.read":              true,
-	"tracking_settings.click.update":            true,
-	"tracking_settings.google_analytics.create": true,
-	"tracking_settings.google_analytics.delete": true,
-	"tracking_settings.google_analytics.read":   true,
-	"tracking_settings.google_analytics.update": true,
-	"tracking_settings.open.create":             true,
-	"tracking_settings.open.delete":             true,
-	"tracking_settings.open.read":               true,
-	"tracking_settings.open.update":             true,
-	"tracking_settings.read":                    true,
-	"tracking_settings.subscription.create":     true,
-	"tracking_settings.subscription.delete":     true,
-	"tracking_settings.subscription.read":       true,
-	"tracking_settings.subscription.update":     true,
-	"ui.confirm_email":                          true,
-	"ui.provision":                              true,
-	"ui.signup_complete":                        true,
-	"user.account.read":                         true,
-	"user.credits.read":                         true,
-	"user.email.read":                           true,
-	"user.profile.create":                       true,
-	"user.profile.delete":                       true,
-	"user.profile.read":                         true,
-	"user.profile.update":                       true,
-	"user.scheduled_sends.create":               true,
-	"user.scheduled_sends.delete":               true,
-	"user.scheduled_sends.read":                 true,
-	"user.scheduled_sends.update":               true,
-	"user.settings.enforced_tls.read":           true,
-	"user.settings.enforced_tls.update":         true,
-	"user.timezone.create":                      true,
-	"user.timezone.delete":                      true,
-	"user.timezone.read":                        true,
-	"user.timezone.update":                      true,
-	"user.username.read":                        true,
-	"user.webhooks.event.settings.create":       true,
-	"user.webhooks.event.settings.delete":       true,
-	"user.webhooks.event.settings.read":         true,
-	"user.webhooks.event.settings.update":       true,
-	"user.webhooks.event.test.create":           true,
-	"user.webhooks.event.test.delete":           true,
-	"user.webhooks.event.test.read":             true,
-	"user.webhooks.event.test.update":           true,
-	"user.webhooks.parse.settings.create":       true,
-	"user.webhooks.parse.settings.delete":       true,
-	"user.webhooks.parse.settings.read":         true,
-	"user.webhooks.parse.settings.update":       true,
-	"user.webhooks.parse.stats.read":            true,
-	"validations.email.create":                  true,
-	"validations.email.read":                    true,
-	"whitelabel.create":                         true,
-	"whitelabel.delete":                         true,
-	"whitelabel.read":                           true,
-	"whitelabel.update":                         true,
-}
-
-// sendgridAutomaticScopes are scopes that SendGrid sets automatically and should not be included in user input
-var sendgridAutomaticScopes = map[string]bool{
-	"2fa_exempt":                 true,
-	"2fa_required":               true,
-	"sender_verification_legacy": true, // SendGrid manages this scope automatically
-}
-
 func resourceSendgridTeammate() *schema.Resource {
 	return &schema.Resource{
 		Description: `Manages a SendGrid teammate. Teammates are team members who have access to your SendGrid account with specific permissions.
@@ -318,6 +50,12 @@ func resourceSendgridTeammate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceSendgridTeammateCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"email": {
@@ -355,15 +93,19 @@ func resourceSendgridTeammate() *schema.Resource {
 			},
 			"scopes": {
 				Type:        schema.TypeSet,
-				Description: "List of permission scopes for the teammate. Ignored if is_admin is true. Cannot include '2fa_exempt' or '2fa_required' as these are managed automatically by SendGrid. See SendGrid API documentation for available scopes.",
+				Description: "List of permission scopes for the teammate. Ignored if is_admin is true. Cannot include '2fa_exempt' or '2fa_required' as these are managed automatically by SendGrid. Validated at plan time: the scopes must be valid, and ones the API key of the provider has. Includes the scopes of scope_presets and scope_patterns. See SendGrid API documentation for available scopes.",
 				Optional:    true,
+				Computed:    true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"scope_presets":  scopePresetsSchema(),
+			"scope_patterns": scopePatternsSchema(),
 			"username": {
 				Type:             schema.TypeString,
 				Optional:         true,
+				Computed:         true,
 				Description:      "The username for the teammate. If not provided, the email will be used.",
 				DiffSuppressFunc: suppressDiffForPendingUsers,
 				Elem: &schema.Schema{
@@ -375,69 +117,289 @@ func resourceSendgridTeammate() *schema.Resource {
 				Computed:    true,
 				Description: "The status of the user: 'active' for confirmed users, 'pending' for users who haven't accepted their invitation yet.",
 			},
+			"subuser_access": {
+				Type:        schema.TypeSet,
+				Description: "Restricts the teammate to these subusers, with admin access or specific scopes on each. Without it, the teammate isn't restricted to subusers. Cannot be used with is_admin.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subuser": {
+							Type:        schema.TypeString,
+							Description: "The username of the subuser.",
+							Required:    true,
+						},
+						"permission_type": {
+							Type:         schema.TypeString,
+							Description:  "The access of the teammate to the subuser: admin, or restricted to the given scopes.",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"admin", "restricted"}, false),
+						},
+						"scopes": {
+							Type:        schema.TypeSet,
+							Description: "The scopes of the teammate on the subuser. Required when permission_type is restricted, empty when it's admin.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"has_restricted_subuser_access": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the teammate is restricted to the subusers of subuser_access.",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the invitation of a pending teammate expires, in RFC 3339 format. Empty once the invitation is accepted.",
+			},
+			"resend_invite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the invitation of a pending teammate is resent at the first apply after it expires, or resend_invite_before it expires, as detected by the last refresh. The resend shows up in the plan as a change of expiration_date.",
+			},
+			"resend_invite_before": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "24h",
+				Description:      "How long before its expiration the invitation is resent, as a duration, e.g. 48h.",
+				ValidateDiagFunc: validateDuration,
+			},
+			"invite_resend_due": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the invitation had expired, or expired within resend_invite_before, at the last refresh: it is resent at the next apply. Like the changes of resend_invite_before, the elapsed time is only taken into account by a refresh.",
+			},
+			"wait_for_accept": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the creation waits for the teammate to accept the invitation, up to the create timeout, so that the resources depending on the teammate find it active.",
+			},
 		},
 	}
 }
 
-// validateTeammateScopes validates the scopes provided for a teammate
-func validateTeammateScopes(v interface{}, path cty.Path) diag.Diagnostics {
-	var diags diag.Diagnostics
-	scopes := v.(*schema.Set).List()
+// sanitizeScopes removes automatic scopes that SendGrid sets automatically
+func sanitizeScopes(scopes []string) []string {
+	return removeScopes(scopes, sendgridAutomaticScopes)
+}
+
+// resourceSendgridTeammateCustomizeDiff expands the scope presets and patterns,
+// and validates the scopes and the access to the subusers at plan time, when they change.
+func resourceSendgridTeammateCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("user_status").(string) == "pending" {
+		return planInviteResend(diff)
+	}
 
-	var invalidScopes []string
-	var automaticScopes []string
+	config := meta.(*Config)
+	catalog := config.scopeCatalog(ctx, "")
+
+	if err := validateTeammateSubuserAccessDiff(diff, catalog); err != nil {
+		return err
+	}
 
-	for _, scope := range scopes {
-		scopeStr := scope.(string)
+	if diff.Get("is_admin").(bool) {
+		return nil
+	}
 
-		// Check for automatic scopes that shouldn't be set manually
-		if sendgridAutomaticScopes[scopeStr] {
-			automaticScopes = append(automaticScopes, scopeStr)
-			continue
+	expanded, err := expandScopesDiff(diff, catalog, sendgridAutomaticScopes, nil)
+	if err != nil {
+		return err
+	}
+
+	// scopes is computed for the expansion, removing them from the configuration must still remove them.
+	if _, configured := configuredScopes(diff); !expanded && !configured && diff.Get("scopes").(*schema.Set).Len() > 0 {
+		if err := diff.SetNew("scopes", []string{}); err != nil {
+			return err
 		}
+	}
+
+	if !diff.NewValueKnown("scopes") || (diff.Id() != "" && !diff.HasChange("scopes")) {
+		return nil
+	}
+
+	scopes := scopesFromSet(diff.Get("scopes").(*schema.Set))
 
-		// Check for invalid scopes
-		if !validSendgridScopes[scopeStr] {
-			invalidScopes = append(invalidScopes, scopeStr)
+	return catalog.check(scopes, sendgridAutomaticScopes).err()
+}
+
+// validateTeammateSubuserAccessDiff validates the access to the subusers when it changes:
+// admins can't be restricted, and the scopes go with the restricted permission only.
+func validateTeammateSubuserAccessDiff(diff *schema.ResourceDiff, catalog *scopeCatalog) error {
+	if !diff.NewValueKnown("subuser_access") || (diff.Id() != "" && !diff.HasChange("subuser_access") && !diff.HasChange("is_admin")) {
+		return nil
+	}
+
+	accesses := diff.Get("subuser_access").(*schema.Set).List()
+	if len(accesses) > 0 && diff.Get("is_admin").(bool) {
+		return ErrTeammateAdminSubuserAccess
+	}
+
+	var errs []error
+
+	for _, v := range accesses {
+		access := v.(map[string]interface{})
+		subuser := access["subuser"].(string)
+		scopes := scopesFromSet(access["scopes"].(*schema.Set))
+
+		switch access["permission_type"].(string) {
+		case "admin":
+			if len(scopes) > 0 {
+				errs = append(errs, fmt.Errorf("%w: subuser %s", ErrTeammateSubuserAdminScopes, subuser))
+			}
+		case "restricted":
+			if len(scopes) == 0 {
+				errs = append(errs, fmt.Errorf("%w: subuser %s", ErrTeammateSubuserScopesRequired, subuser))
+			}
+		}
+
+		if err := catalog.check(scopes, sendgridAutomaticScopes).err(); err != nil {
+			errs = append(errs, fmt.Errorf("subuser %s: %w", subuser, err))
 		}
 	}
 
-	if len(automaticScopes) > 0 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Automatic scopes cannot be manually assigned",
-			Detail: fmt.Sprintf(
-				"the following scopes are set automatically by SendGrid and cannot be manually assigned: %s",
-				strings.Join(automaticScopes, ", "),
-			),
-			AttributePath: path,
+	return errors.Join(errs...)
+}
+
+// expandTeammateSubuserAccess returns the access to the subusers of the configuration,
+// with the IDs of the subusers which SendGrid expects.
+func expandTeammateSubuserAccess(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *sendgrid.Client,
+) (*sendgrid.TeammateSubuserAccess, error) {
+	access := &sendgrid.TeammateSubuserAccess{}
+
+	for _, v := range d.Get("subuser_access").(*schema.Set).List() {
+		subuserAccess := v.(map[string]interface{})
+		username := subuserAccess["subuser"].(string)
+
+		subusersStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return client.ReadSubUser(ctx, username)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		subusers := subusersStruct.([]sendgrid.SubUser)
+		if len(subusers) == 0 {
+			return nil, subUserNotFound(username)
+		}
+
+		scopes := sanitizeScopes(scopesFromSet(subuserAccess["scopes"].(*schema.Set)))
+		if scopes == nil {
+			scopes = []string{}
+		}
+
+		access.Subusers = append(access.Subusers, sendgrid.SubuserAccess{
+			ID:             subusers[0].ID,
+			PermissionType: subuserAccess["permission_type"].(string),
+			Scopes:         scopes,
 		})
 	}
 
-	if len(invalidScopes) > 0 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Invalid or unassignable scopes",
-			Detail: fmt.Sprintf(
-				"the following scopes are not valid or assignable: %s. Please check the SendGrid API documentation for valid scopes",
-				strings.Join(invalidScopes, ", "),
-			),
-			AttributePath: path,
+	return access, nil
+}
+
+func flattenTeammateSubuserAccess(accesses []sendgrid.SubuserAccess) []interface{} {
+	flattened := make([]interface{}, 0, len(accesses))
+
+	for _, access := range accesses {
+		// Admins of a subuser have all its scopes, they're not tracked like for the admins of the account.
+		var scopes []string
+		if access.PermissionType != "admin" {
+			scopes = sanitizeScopes(access.Scopes)
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"subuser":         access.Username,
+			"permission_type": access.PermissionType,
+			"scopes":          scopes,
 		})
 	}
 
-	return diags
+	return flattened
 }
 
-// sanitizeScopes removes automatic scopes that SendGrid sets automatically
-func sanitizeScopes(scopes []string) []string {
-	var sanitized []string
-	for _, scope := range scopes {
-		if !sendgridAutomaticScopes[scope] {
-			sanitized = append(sanitized, scope)
+// inviteExpirationDate returns the expiration of the invitation of a pending user, in RFC 3339 format.
+func inviteExpirationDate(user *sendgrid.User) string {
+	if user.UserType != "pending" || user.ExpirationDate == 0 {
+		return ""
+	}
+
+	return time.Unix(user.ExpirationDate, 0).UTC().Format(time.RFC3339)
+}
+
+// planInviteResend plans the resend of the invitation of a pending user, when the last refresh found it due.
+func planInviteResend(diff *schema.ResourceDiff) error {
+	if !diff.Get("resend_invite").(bool) || !diff.Get("invite_resend_due").(bool) {
+		return nil
+	}
+
+	for _, key := range []string{"expiration_date", "invite_resend_due"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
 		}
 	}
-	return sanitized
+
+	return nil
+}
+
+// setInviteResendDue sets whether the invitation has expired or expires within resend_invite_before.
+// Like time_rotating, the expiry is detected by the refresh, so that the plan only follows the state.
+func setInviteResendDue(d *schema.ResourceData, now time.Time) {
+	before, _ := time.ParseDuration(d.Get("resend_invite_before").(string))
+	due := d.Get("user_status").(string) == "pending" && d.Get("resend_invite").(bool) &&
+		inviteNeedsResend(d.Get("expiration_date").(string), before, now)
+
+	//nolint:errcheck
+	d.Set("invite_resend_due", due)
+}
+
+func inviteNeedsResend(expirationDate string, before time.Duration, now time.Time) bool {
+	expiration, err := time.Parse(time.RFC3339, expirationDate)
+	if err != nil {
+		return false
+	}
+
+	return !now.Add(before).Before(expiration)
+}
+
+// waitForInviteAccept waits for a pending user to accept its invitation, up to the create timeout.
+// The teammates are listed again at each attempt, rather than taken from the cache of the client.
+func waitForInviteAccept(ctx context.Context, d *schema.ResourceData, client *sendgrid.Client, email string) diag.Diagnostics {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"active"},
+		Refresh: func() (interface{}, string, error) {
+			usersStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+				return client.ReadUsers(ctx)
+			})
+			if err != nil {
+				return nil, "", err
+			}
+
+			for _, user := range usersStruct.([]sendgrid.User) {
+				if user.Email == email {
+					return user, "active", nil
+				}
+			}
+
+			return email, "pending", nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		PollInterval: 30 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(fmt.Errorf("%s hasn't accepted the invitation: %w", email, err))
+	}
+
+	return nil
 }
 
 // suppressDiffForPendingUsers suppresses diff for fields that are not available for pending users
@@ -501,7 +463,7 @@ func resourceSendgridTeammateCreate(ctx context.Context, d *schema.ResourceData,
 	// Validate scopes if not admin
 	if !isAdmin && scopesSet.Len() > 0 {
 		path := cty.GetAttrPath("scopes")
-		if diags := validateTeammateScopes(scopesSet, path); diags.HasError() {
+		if diags := validateScopes(config.scopeCatalog(ctx, ""), scopesSet, sendgridAutomaticScopes, path); diags.HasError() {
 			return diags
 		}
 	}
@@ -521,11 +483,19 @@ func resourceSendgridTeammateCreate(ctx context.Context, d *schema.ResourceData,
 		"email": email, "is_admin": isAdmin, "scopes": scopes,
 	})
 
+	var access *sendgrid.TeammateSubuserAccess
+	if d.Get("subuser_access").(*schema.Set).Len() > 0 {
+		var err error
+		if access, err = expandTeammateSubuserAccess(ctx, d, client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	userStruct, err := enhancedRetryOnScopeErrors(ctx, d, func() (interface{}, sendgrid.RequestError) {
 		if isSSO {
-			return client.CreateSSOUser(ctx, firstName, lastName, email, scopes, isAdmin)
+			return client.CreateSSOUser(ctx, firstName, lastName, email, scopes, isAdmin, access)
 		} else {
-			return client.CreateUser(ctx, email, scopes, isAdmin)
+			return client.CreateUser(ctx, email, scopes, isAdmin, access)
 		}
 	})
 	if err != nil {
@@ -538,18 +508,47 @@ func resourceSendgridTeammateCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	//nolint:errcheck
+	d.Set("has_restricted_subuser_access", access != nil)
+
+	if d.Get("wait_for_accept").(bool) {
+		return waitForInviteAccept(ctx, d, client, user.Email)
+	}
+
 	return nil
 }
 
 func resourceSendgridTeammateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := readTeammate(ctx, d, meta)
+	if !diags.HasError() {
+		setInviteResendDue(d, time.Now())
+	}
+
+	return diags
+}
+
+// resourceSendgridTeammateReadAfterApply reads the teammate after an update, whether the invitation is due
+// is only set when it was planned as unknown, so that the result of the apply is the planned one.
+func resourceSendgridTeammateReadAfterApply(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := readTeammate(ctx, d, meta)
+	if plan := d.GetRawPlan(); !diags.HasError() && !plan.IsNull() && !plan.GetAttr("invite_resend_due").IsKnown() {
+		setInviteResendDue(d, time.Now())
+	}
+
+	return diags
+}
+
+func readTeammate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 	client := config.NewClient("")
 
 	var diags diag.Diagnostics
 	email := d.Id()
+	// The username of the state avoids listing the teammates, the pending ones have none.
+	username := d.Get("username").(string)
 
 	teammateStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
-		return client.ReadUser(ctx, email)
+		return client.ReadUserWithUsername(ctx, email, username)
 	})
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
@@ -591,8 +590,17 @@ func resourceSendgridTeammateRead(ctx context.Context, d *schema.ResourceData, m
 		d.Set("scopes", filteredScopes),
 		d.Set("is_admin", teammate.IsAdmin),
 		d.Set("user_status", userStatus),
+		d.Set("expiration_date", inviteExpirationDate(teammate)),
 	)
 
+	// The pending invitations don't report the access to the subusers, the one of the state is kept.
+	if userStatus != "pending" {
+		retErr = multierror.Append(retErr,
+			d.Set("subuser_access", flattenTeammateSubuserAccess(teammate.SubuserAccess)),
+			d.Set("has_restricted_subuser_access", teammate.HasRestrictedSubuserAccess),
+		)
+	}
+
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
@@ -608,11 +616,24 @@ func resourceSendgridTeammateUpdate(ctx context.Context, d *schema.ResourceData,
 	// Check if user is pending - pending users are read-only after invitation is sent
 	userStatus := d.Get("user_status").(string)
 	if userStatus == "pending" {
+		// A resend is planned by the diff, which marks expiration_date as unknown.
+		if d.HasChange("expiration_date") {
+			tflog.Info(ctx, "Resending the invitation of a pending user", map[string]interface{}{
+				"email": email,
+			})
+
+			if _, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+				return client.ResendPendingUser(ctx, email)
+			}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
 		tflog.Info(ctx, "Pending user detected - skipping update. Pending users are read-only until they accept their invitation", map[string]interface{}{
 			"email": email,
 		})
 		// For pending users, we only refresh their current state
-		return resourceSendgridTeammateRead(ctx, d, meta)
+		return resourceSendgridTeammateReadAfterApply(ctx, d, meta)
 	}
 
 	scopesSet := d.Get("scopes").(*schema.Set)
@@ -620,7 +641,7 @@ func resourceSendgridTeammateUpdate(ctx context.Context, d *schema.ResourceData,
 	// Validate scopes if not admin
 	if !isAdmin && scopesSet.Len() > 0 {
 		path := cty.GetAttrPath("scopes")
-		if diags := validateTeammateScopes(scopesSet, path); diags.HasError() {
+		if diags := validateScopes(config.scopeCatalog(ctx, ""), scopesSet, sendgridAutomaticScopes, path); diags.HasError() {
 			return diags
 		}
 	}
@@ -635,11 +656,20 @@ func resourceSendgridTeammateUpdate(ctx context.Context, d *schema.ResourceData,
 		scopes = sanitizeScopes(scopes)
 	}
 
+	// The access to the subusers is only sent when it changes, an empty one lifts the restriction.
+	var access *sendgrid.TeammateSubuserAccess
+	if d.HasChange("subuser_access") {
+		var err error
+		if access, err = expandTeammateSubuserAccess(ctx, d, client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	_, err := enhancedRetryOnScopeErrors(ctx, d, func() (interface{}, sendgrid.RequestError) {
 		if isSSO {
-			return client.UpdateSSOUser(ctx, firstName, lastName, email, scopes, isAdmin)
+			return client.UpdateSSOUser(ctx, firstName, lastName, email, scopes, isAdmin, access)
 		} else {
-			return client.UpdateUser(ctx, email, scopes, isAdmin)
+			return client.UpdateUser(ctx, email, scopes, isAdmin, access)
 		}
 	})
 
@@ -647,7 +677,7 @@ func resourceSendgridTeammateUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return resourceSendgridTeammateRead(ctx, d, meta)
+	return resourceSendgridTeammateReadAfterApply(ctx, d, meta)
 }
 
 func resourceSendgridTeammateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
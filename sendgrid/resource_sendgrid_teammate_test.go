@@ -104,6 +104,31 @@ func TestAccSendgridTeammate_automaticScopes(t *testing.T) {
 	})
 }
 
+func TestAccSendgridTeammate_subuserAccess(t *testing.T) {
+	email := "terraform-subuser-access-" + acctest.RandString(10) + "@example.com"
+	username := "terraform-subuser-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridTeammateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckSendgridTeammateConfigSubuserAccess(email, username, true),
+				ExpectError: regexp.MustCompile("subuser_access requires is_admin to be false"),
+			},
+			{
+				Config: testAccCheckSendgridTeammateConfigSubuserAccess(email, username, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridTeammateExists("sendgrid_teammate.test"),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "subuser_access.#", "1"),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "has_restricted_subuser_access", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSendgridTeammatePendingUser(t *testing.T) {
 	email := "terraform-teammate-pending-test-" + acctest.RandString(10) + "@example.com"
 
@@ -121,6 +146,7 @@ func TestAccSendgridTeammatePendingUser(t *testing.T) {
 					resource.TestCheckResourceAttr("sendgrid_teammate.test", "is_sso", "false"),
 					// For non-SSO users, status should be pending initially
 					resource.TestCheckResourceAttr("sendgrid_teammate.test", "user_status", "pending"),
+					resource.TestCheckResourceAttrSet("sendgrid_teammate.test", "expiration_date"),
 				),
 			},
 			// Test that we can update a pending user
@@ -245,3 +271,25 @@ resource "sendgrid_teammate" "test" {
 }
 `, email)
 }
+
+func testAccCheckSendgridTeammateConfigSubuserAccess(email, username string, isAdmin bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_subuser" "test" {
+	username = "%s"
+	email    = "%s@example.com"
+	password = "TerraformTest123!"
+}
+
+resource "sendgrid_teammate" "test" {
+	email    = "%s"
+	is_admin = %t
+	is_sso   = false
+
+	subuser_access {
+		subuser         = sendgrid_subuser.test.username
+		permission_type = "restricted"
+		scopes          = ["mail.send", "stats.read"]
+	}
+}
+`, username, username, email, isAdmin)
+}
@@ -0,0 +1,576 @@
+/*
+Provide a resource to manage many sendgrid teammates at once.
+Example Usage
+```hcl
+
+	resource "sendgrid_teammates" "team" {
+		teammate {
+			email  = "ada@example.com"
+			scopes = ["mail.send"]
+		}
+
+		teammate {
+			email    = "grace@example.com"
+			is_admin = true
+		}
+	}
+
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSendgridTeammates() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages many SendGrid teammates at once, e.g. from a map of emails. " +
+			"The teammates are read with one listing of the teammates and one of the pending invitations per refresh, " +
+			"then one read of each teammate which isn't an admin, since the listing doesn't include the scopes. " +
+			"The invitations, updates and removals are made in batches retried when they're rate limited. " +
+			"Only the teammates of the resource are managed, the other teammates of the account are left untouched.",
+
+		CreateContext: resourceSendgridTeammatesCreate,
+		ReadContext:   resourceSendgridTeammatesRead,
+		UpdateContext: resourceSendgridTeammatesUpdate,
+		DeleteContext: resourceSendgridTeammatesDelete,
+		CustomizeDiff: resourceSendgridTeammatesCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"teammate": {
+				Type:        schema.TypeSet,
+				Description: "The teammates, one block per email.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:        schema.TypeString,
+							Description: "The email address of the teammate, unique among the teammate blocks.",
+							Required:    true,
+						},
+						"is_admin": {
+							Type:        schema.TypeBool,
+							Description: "Whether the teammate has admin privileges, in which case it has no scopes.",
+							Optional:    true,
+							Default:     false,
+						},
+						"is_sso": {
+							Type:        schema.TypeBool,
+							Description: "Whether the teammate is a Single Sign-On (SSO) user. SSO users require first_name and last_name.",
+							Optional:    true,
+							Default:     false,
+						},
+						"first_name": {
+							Type:        schema.TypeString,
+							Description: "The first name of an SSO teammate.",
+							Optional:    true,
+						},
+						"last_name": {
+							Type:        schema.TypeString,
+							Description: "The last name of an SSO teammate.",
+							Optional:    true,
+						},
+						"scopes": {
+							Type:        schema.TypeSet,
+							Description: "The scopes of the teammate, without the ones set automatically by SendGrid. Validated at plan time like the ones of sendgrid_teammate.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Description:  "How many requests are made concurrently when reading, inviting, updating and removing the teammates.",
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 20),
+			},
+			"status": {
+				Type:        schema.TypeMap,
+				Description: "The status of each teammate by email: 'active' for confirmed users, 'pending' for users who haven't accepted their invitation yet.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"usernames": {
+				Type:        schema.TypeMap,
+				Description: "The username of each active teammate by email.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// teammateSpec is a teammate managed in bulk.
+type teammateSpec struct {
+	Email     string
+	IsAdmin   bool
+	IsSSO     bool
+	FirstName string
+	LastName  string
+	Scopes    []string
+}
+
+func (s teammateSpec) equal(other teammateSpec) bool {
+	if s.Email != other.Email || s.IsAdmin != other.IsAdmin || s.IsSSO != other.IsSSO ||
+		s.FirstName != other.FirstName || s.LastName != other.LastName || len(s.Scopes) != len(other.Scopes) {
+		return false
+	}
+
+	for i := range s.Scopes {
+		if s.Scopes[i] != other.Scopes[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func expandTeammateSpecs(v interface{}) map[string]teammateSpec {
+	specs := map[string]teammateSpec{}
+
+	for _, raw := range v.(*schema.Set).List() {
+		teammate := raw.(map[string]interface{})
+
+		scopes := sanitizeScopes(scopesFromSet(teammate["scopes"].(*schema.Set)))
+		sort.Strings(scopes)
+
+		spec := teammateSpec{
+			Email:     teammate["email"].(string),
+			IsAdmin:   teammate["is_admin"].(bool),
+			IsSSO:     teammate["is_sso"].(bool),
+			FirstName: teammate["first_name"].(string),
+			LastName:  teammate["last_name"].(string),
+			Scopes:    scopes,
+		}
+		specs[spec.Email] = spec
+	}
+
+	return specs
+}
+
+func flattenTeammateSpecs(specs map[string]teammateSpec) []interface{} {
+	flattened := make([]interface{}, 0, len(specs))
+
+	for _, spec := range specs {
+		flattened = append(flattened, map[string]interface{}{
+			"email":      spec.Email,
+			"is_admin":   spec.IsAdmin,
+			"is_sso":     spec.IsSSO,
+			"first_name": spec.FirstName,
+			"last_name":  spec.LastName,
+			"scopes":     spec.Scopes,
+		})
+	}
+
+	return flattened
+}
+
+// teammatesPlan is what it takes to go from some teammates to others.
+type teammatesPlan struct {
+	invite []teammateSpec
+	update []teammateSpec
+	remove []teammateSpec
+}
+
+// planTeammates compares the teammates by email, the lists are sorted by email.
+func planTeammates(current, desired map[string]teammateSpec) teammatesPlan {
+	var plan teammatesPlan
+
+	for email, spec := range desired {
+		old, ok := current[email]
+
+		switch {
+		case !ok:
+			plan.invite = append(plan.invite, spec)
+		case !old.equal(spec):
+			plan.update = append(plan.update, spec)
+		}
+	}
+
+	for email, spec := range current {
+		if _, ok := desired[email]; !ok {
+			plan.remove = append(plan.remove, spec)
+		}
+	}
+
+	for _, specs := range [][]teammateSpec{plan.invite, plan.update, plan.remove} {
+		sort.Slice(specs, func(i, j int) bool { return specs[i].Email < specs[j].Email })
+	}
+
+	return plan
+}
+
+// inBatches calls f for the teammates, batchSize of them at a time.
+// The errors of all the teammates are returned together, so that one failure doesn't stop the others.
+func inBatches(specs []teammateSpec, batchSize int, f func(teammateSpec) error) error {
+	var (
+		errs  []error
+		mutex sync.Mutex
+	)
+
+	for start := 0; start < len(specs); start += batchSize {
+		end := min(start+batchSize, len(specs))
+
+		var wg sync.WaitGroup
+		for _, spec := range specs[start:end] {
+			wg.Add(1)
+
+			go func(spec teammateSpec) {
+				defer wg.Done()
+
+				if err := f(spec); err != nil {
+					mutex.Lock()
+					errs = append(errs, fmt.Errorf("%s: %w", spec.Email, err))
+					mutex.Unlock()
+				}
+			}(spec)
+		}
+
+		wg.Wait()
+	}
+
+	return errors.Join(errs...)
+}
+
+// teammatesState is the state of the teammates of the account, as listed by SendGrid.
+type teammatesState struct {
+	specs     map[string]teammateSpec
+	status    map[string]string
+	usernames map[string]string
+}
+
+// readTeammates reads the given teammates with one listing of the active teammates,
+// one of the pending invitations when some teammates aren't active,
+// and one read of each active teammate which isn't an admin, for its scopes.
+// The known specs provide what SendGrid doesn't return, like the names of the teammates which aren't SSO users.
+func readTeammates(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *sendgrid.Client,
+	known map[string]teammateSpec,
+	batchSize int,
+) (*teammatesState, error) {
+	state := &teammatesState{
+		specs:     map[string]teammateSpec{},
+		status:    map[string]string{},
+		usernames: map[string]string{},
+	}
+
+	if len(known) == 0 {
+		return state, nil
+	}
+
+	usersStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return client.ReadUsers(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	active := map[string]sendgrid.User{}
+	for _, user := range usersStruct.([]sendgrid.User) {
+		if _, ok := known[user.Email]; ok {
+			active[user.Email] = user
+		}
+	}
+
+	if len(active) < len(known) {
+		pendingStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return client.ReadPendingUsers(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range pendingStruct.([]sendgrid.User) {
+			spec, ok := known[user.Email]
+			if _, isActive := active[user.Email]; !ok || isActive {
+				continue
+			}
+
+			spec.IsAdmin = user.IsAdmin
+			spec.Scopes = teammateScopes(&user)
+			state.specs[user.Email] = spec
+			state.status[user.Email] = "pending"
+		}
+	}
+
+	var mutex sync.Mutex
+
+	actives := make([]teammateSpec, 0, len(active))
+	for email := range active {
+		actives = append(actives, known[email])
+	}
+
+	err = inBatches(actives, batchSize, func(spec teammateSpec) error {
+		user := active[spec.Email]
+
+		// The scopes of the admins aren't tracked, there's no need to read them.
+		if !user.IsAdmin {
+			userStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+				return client.ReadUserByUsername(ctx, user.Username)
+			})
+			if err != nil {
+				return err
+			}
+
+			user = *userStruct.(*sendgrid.User)
+		}
+
+		spec.IsAdmin = user.IsAdmin
+		spec.Scopes = teammateScopes(&user)
+
+		if spec.IsSSO {
+			spec.FirstName = user.FirstName
+			spec.LastName = user.LastName
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		state.specs[spec.Email] = spec
+		state.status[spec.Email] = "active"
+		state.usernames[spec.Email] = user.Username
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// teammateScopes returns the scopes of a teammate which are managed, sorted: none for the admins.
+func teammateScopes(user *sendgrid.User) []string {
+	if user.IsAdmin {
+		return nil
+	}
+
+	scopes := sanitizeScopes(user.Scopes)
+	sort.Strings(scopes)
+
+	return scopes
+}
+
+// applyTeammates invites, updates and removes the teammates of the plan.
+// The pending teammates can't be updated, their invitation is replaced instead.
+// It returns the teammates which couldn't be removed, so that they're kept in the state.
+func applyTeammates(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *sendgrid.Client,
+	plan teammatesPlan,
+	status map[string]string,
+	batchSize int,
+) ([]teammateSpec, error) {
+	tflog.Info(ctx, "Reconciling teammates", map[string]interface{}{
+		"invite": len(plan.invite),
+		"update": len(plan.update),
+		"remove": len(plan.remove),
+	})
+
+	// The updates and removals look up the usernames of the teammates: they're listed once,
+	// instead of by each request of the first batch.
+	if len(plan.update) > 0 || len(plan.remove) > 0 {
+		if _, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return client.ReadUsers(ctx)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	invite := func(spec teammateSpec) error {
+		_, err := enhancedRetryOnScopeErrors(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			if spec.IsSSO {
+				return client.CreateSSOUser(ctx, spec.FirstName, spec.LastName, spec.Email, spec.Scopes, spec.IsAdmin, nil)
+			}
+
+			return client.CreateUser(ctx, spec.Email, spec.Scopes, spec.IsAdmin, nil)
+		})
+
+		return err
+	}
+
+	remove := func(spec teammateSpec) error {
+		_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return client.DeleteUser(ctx, spec.Email)
+		})
+
+		return err
+	}
+
+	var (
+		notRemoved []teammateSpec
+		mutex      sync.Mutex
+	)
+
+	removeErr := inBatches(plan.remove, batchSize, func(spec teammateSpec) error {
+		err := remove(spec)
+		if err != nil {
+			mutex.Lock()
+			notRemoved = append(notRemoved, spec)
+			mutex.Unlock()
+		}
+
+		return err
+	})
+
+	updateErr := inBatches(plan.update, batchSize, func(spec teammateSpec) error {
+		if status[spec.Email] == "pending" {
+			if err := remove(spec); err != nil {
+				return err
+			}
+
+			return invite(spec)
+		}
+
+		_, err := enhancedRetryOnScopeErrors(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			if spec.IsSSO {
+				return client.UpdateSSOUser(ctx, spec.FirstName, spec.LastName, spec.Email, spec.Scopes, spec.IsAdmin, nil)
+			}
+
+			return client.UpdateUser(ctx, spec.Email, spec.Scopes, spec.IsAdmin, nil)
+		})
+
+		return err
+	})
+
+	inviteErr := inBatches(plan.invite, batchSize, invite)
+
+	return notRemoved, errors.Join(removeErr, updateErr, inviteErr)
+}
+
+// resourceSendgridTeammatesCustomizeDiff validates the teammates at plan time, when they change.
+func resourceSendgridTeammatesCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("teammate") || !diff.HasChange("teammate") {
+		return nil
+	}
+
+	catalog := meta.(*Config).scopeCatalog(ctx, "")
+
+	var errs []error
+
+	// The teammates are keyed by email, a duplicate would silently overwrite the other block.
+	seen := map[string]bool{}
+
+	for _, v := range diff.Get("teammate").(*schema.Set).List() {
+		teammate := v.(map[string]interface{})
+		email := teammate["email"].(string)
+		scopes := scopesFromSet(teammate["scopes"].(*schema.Set))
+
+		if seen[strings.ToLower(email)] {
+			errs = append(errs, fmt.Errorf("%s: %w", email, ErrTeammateDuplicateEmail))
+		}
+
+		seen[strings.ToLower(email)] = true
+
+		if teammate["is_admin"].(bool) && len(scopes) > 0 {
+			errs = append(errs, fmt.Errorf("%s: %w", email, ErrTeammateAdminScopes))
+		}
+
+		if err := catalog.check(scopes, sendgridAutomaticScopes).err(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", email, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func resourceSendgridTeammatesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(id.UniqueId())
+
+	return resourceSendgridTeammatesApply(ctx, d, meta, map[string]teammateSpec{})
+}
+
+func resourceSendgridTeammatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).NewClient("")
+
+	state, err := readTeammates(ctx, d, client, expandTeammateSpecs(d.Get("teammate")), d.Get("batch_size").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(setTeammatesState(d, state))
+}
+
+func resourceSendgridTeammatesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	old, _ := d.GetChange("teammate")
+
+	return resourceSendgridTeammatesApply(ctx, d, meta, expandTeammateSpecs(old))
+}
+
+// resourceSendgridTeammatesApply reconciles the teammates of the state with the ones of the configuration,
+// then reads them back, so that the state has the teammates which were reconciled even when others failed.
+func resourceSendgridTeammatesApply(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	current map[string]teammateSpec,
+) diag.Diagnostics {
+	client := meta.(*Config).NewClient("")
+	batchSize := d.Get("batch_size").(int)
+	desired := expandTeammateSpecs(d.Get("teammate"))
+
+	status := map[string]string{}
+	for email, s := range d.Get("status").(map[string]interface{}) {
+		status[email] = s.(string)
+	}
+
+	notRemoved, applyErr := applyTeammates(ctx, d, client, planTeammates(current, desired), status, batchSize)
+
+	for _, spec := range notRemoved {
+		desired[spec.Email] = spec
+	}
+
+	state, err := readTeammates(ctx, d, client, desired, batchSize)
+	if err != nil {
+		return diag.FromErr(errors.Join(applyErr, err))
+	}
+
+	return diag.FromErr(errors.Join(applyErr, setTeammatesState(d, state)))
+}
+
+func resourceSendgridTeammatesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).NewClient("")
+	current := expandTeammateSpecs(d.Get("teammate"))
+
+	_, err := applyTeammates(ctx, d, client, planTeammates(current, nil), nil, d.Get("batch_size").(int))
+
+	return diag.FromErr(err)
+}
+
+func setTeammatesState(d *schema.ResourceData, state *teammatesState) error {
+	return errors.Join(
+		d.Set("teammate", flattenTeammateSpecs(state.specs)),
+		d.Set("status", state.status),
+		d.Set("usernames", state.usernames),
+	)
+}
@@ -0,0 +1,72 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridTeammates_basic(t *testing.T) {
+	prefix := "terraform-teammates-" + acctest.RandString(10)
+	ada := prefix + "-ada@example.com"
+	grace := prefix + "-grace@example.com"
+	linus := prefix + "-linus@example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridTeammateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTeammatesConfig(map[string]string{
+					ada:   `["mail.send"]`,
+					grace: `["mail.send", "stats.read"]`,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_teammates.test", "teammate.#", "2"),
+					resource.TestCheckResourceAttr("sendgrid_teammates.test", "status.%", "2"),
+					resource.TestCheckResourceAttr("sendgrid_teammates.test", "status."+ada, "pending"),
+				),
+			},
+			// grace is updated, linus invited and ada removed.
+			{
+				Config: testAccCheckSendgridTeammatesConfig(map[string]string{
+					grace: `["mail.send"]`,
+					linus: `["stats.read"]`,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_teammates.test", "teammate.#", "2"),
+					resource.TestCheckNoResourceAttr("sendgrid_teammates.test", "status."+ada),
+					resource.TestCheckResourceAttr("sendgrid_teammates.test", "status."+linus, "pending"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridTeammatesConfig(teammates map[string]string) string {
+	locals := ""
+	for email, scopes := range teammates {
+		locals += fmt.Sprintf("\t\t%q = %s\n", email, scopes)
+	}
+
+	return fmt.Sprintf(`
+locals {
+	teammates = {
+%s	}
+}
+
+resource "sendgrid_teammates" "test" {
+	dynamic "teammate" {
+		for_each = local.teammates
+
+		content {
+			email  = teammate.key
+			scopes = teammate.value
+		}
+	}
+}
+`, locals)
+}
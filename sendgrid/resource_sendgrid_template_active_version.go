@@ -0,0 +1,305 @@
+/*
+Provide a resource to manage which version of a template is active.
+Example Usage
+```hcl
+
+	resource "sendgrid_template_active_version" "welcome" {
+		template_id = sendgrid_template.welcome.id
+		version_id  = var.green ? sendgrid_template_version.green.id : sendgrid_template_version.blue.id
+	}
+
+```
+Import
+The active version of a template can be imported, e.g.
+```hcl
+$ terraform import sendgrid_template_active_version.welcome templateID
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSendgridTemplateActiveVersion() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages which version of a template is active, " +
+			"with blue/green switches and rollbacks to the previously active version.",
+		CreateContext: resourceSendgridTemplateActiveVersionCreate,
+		ReadContext:   resourceSendgridTemplateActiveVersionRead,
+		UpdateContext: resourceSendgridTemplateActiveVersionUpdate,
+		DeleteContext: resourceSendgridTemplateActiveVersionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSendgridTemplateActiveVersionImport,
+		},
+		CustomizeDiff: resourceSendgridTemplateActiveVersionCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the transactional template.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version_id": {
+				Type: schema.TypeString,
+				Description: "ID of the version to activate. Switching it between two versions " +
+					"performs a blue/green deployment of the template.",
+				Required: true,
+			},
+			"rollback": {
+				Type: schema.TypeBool,
+				Description: "Activate `previous_version_id` instead of `version_id`, " +
+					"to roll back a deployment without editing `version_id`.",
+				Optional: true,
+				Default:  false,
+			},
+			"active_version_id": {
+				Type: schema.TypeString,
+				Description: "ID of the version which is active in SendGrid. " +
+					"A version activated outside of this resource shows up as a change of this attribute.",
+				Computed: true,
+			},
+			"previous_version_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the version which was active before `version_id` was last activated.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSendgridTemplateActiveVersionCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	templateID := d.Get("template_id").(string)
+	versionID := d.Get("version_id").(string)
+
+	if d.Get("rollback").(bool) {
+		return diag.FromErr(ErrTemplateActiveVersionNoPrevious)
+	}
+
+	activeVersionID, err := readTemplateActiveVersionID(ctx, d, m, templateID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	previousVersionID := ""
+	if activeVersionID != versionID {
+		previousVersionID = activeVersionID
+	}
+
+	if err := activateTemplateVersion(ctx, d, m, templateID, versionID, activeVersionID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	//nolint:errcheck
+	d.Set("previous_version_id", previousVersionID)
+	d.SetId(templateID)
+
+	return resourceSendgridTemplateActiveVersionRead(ctx, d, m)
+}
+
+func resourceSendgridTemplateActiveVersionRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	activeVersionID, err := readTemplateActiveVersionID(ctx, d, m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	//nolint:errcheck
+	d.Set("template_id", d.Id())
+
+	if err := d.Set("active_version_id", activeVersionID); err != nil {
+		return diag.FromErr(ErrSetTemplateActiveVersionID)
+	}
+
+	wanted := templateWantedActiveVersionID(d.Get("version_id").(string), d.Get("previous_version_id").(string),
+		d.Get("rollback").(bool))
+	if wanted == "" || wanted == activeVersionID {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Template version activated outside of this resource",
+		Detail: fmt.Sprintf("Version %q of template %q is active instead of %q. "+
+			"Another tool, the SendGrid UI or a sendgrid_template_version with active = 1 activated it: "+
+			"remove active from the template versions managed by sendgrid_template_active_version, "+
+			"otherwise each apply switches the active version back and forth.",
+			activeVersionID, d.Id(), wanted),
+	}}
+}
+
+func resourceSendgridTemplateActiveVersionUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	templateID := d.Id()
+
+	activeVersionID, err := readTemplateActiveVersionID(ctx, d, m, templateID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	versionID := d.Get("version_id").(string)
+	previousVersionID := d.Get("previous_version_id").(string)
+
+	if d.HasChange("version_id") && activeVersionID != versionID {
+		previousVersionID = activeVersionID
+	}
+
+	rollback := d.Get("rollback").(bool)
+	if rollback && previousVersionID == "" {
+		return diag.FromErr(ErrTemplateActiveVersionNoPrevious)
+	}
+
+	wanted := templateWantedActiveVersionID(versionID, previousVersionID, rollback)
+	if err := activateTemplateVersion(ctx, d, m, templateID, wanted, activeVersionID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	//nolint:errcheck
+	d.Set("previous_version_id", previousVersionID)
+
+	return resourceSendgridTemplateActiveVersionRead(ctx, d, m)
+}
+
+// resourceSendgridTemplateActiveVersionDelete only removes the resource from the state:
+// SendGrid has no way to deactivate the active version of a template.
+func resourceSendgridTemplateActiveVersionDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+func resourceSendgridTemplateActiveVersionImport(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) ([]*schema.ResourceData, error) {
+	activeVersionID, err := readTemplateActiveVersionID(ctx, d, m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	d.Set("template_id", d.Id())
+	//nolint:errcheck
+	d.Set("version_id", activeVersionID)
+	//nolint:errcheck
+	d.Set("rollback", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceSendgridTemplateActiveVersionCustomizeDiff plans the version which becomes active,
+// so that blue/green switches, rollbacks and activations made elsewhere show up in the plan.
+func resourceSendgridTemplateActiveVersionCustomizeDiff(
+	_ context.Context,
+	diff *schema.ResourceDiff,
+	_ interface{},
+) error {
+	if !diff.NewValueKnown("version_id") {
+		if diff.Id() != "" {
+			if err := diff.SetNewComputed("previous_version_id"); err != nil {
+				return err
+			}
+		}
+
+		return diff.SetNewComputed("active_version_id")
+	}
+
+	versionID := diff.Get("version_id").(string)
+	activeVersionID := diff.Get("active_version_id").(string)
+	previousVersionID := diff.Get("previous_version_id").(string)
+
+	if diff.Id() != "" && diff.HasChange("version_id") && activeVersionID != versionID {
+		previousVersionID = activeVersionID
+		if err := diff.SetNew("previous_version_id", previousVersionID); err != nil {
+			return err
+		}
+	}
+
+	rollback := diff.Get("rollback").(bool)
+	if rollback && (diff.Id() == "" || previousVersionID == "") {
+		return ErrTemplateActiveVersionNoPrevious
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	wanted := templateWantedActiveVersionID(versionID, previousVersionID, rollback)
+	if wanted != activeVersionID {
+		return diff.SetNew("active_version_id", wanted)
+	}
+
+	return nil
+}
+
+func templateWantedActiveVersionID(versionID, previousVersionID string, rollback bool) string {
+	if rollback {
+		return previousVersionID
+	}
+
+	return versionID
+}
+
+// readTemplateActiveVersionID returns the ID of the active version of a template, empty when none is active.
+func readTemplateActiveVersionID(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+	templateID string,
+) (string, error) {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	templateStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadTemplate(ctx, templateID)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	template := templateStruct.(*sendgrid.Template)
+	for _, version := range template.Versions {
+		if version.Active == 1 {
+			return version.ID, nil
+		}
+	}
+
+	return "", nil
+}
+
+func activateTemplateVersion(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+	templateID, versionID, activeVersionID string,
+) error {
+	if versionID == activeVersionID {
+		return nil
+	}
+
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ActivateTemplateVersion(ctx, sendgrid.TemplateVersion{ID: versionID, TemplateID: templateID})
+	})
+
+	return err
+}
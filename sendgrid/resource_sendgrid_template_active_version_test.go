@@ -0,0 +1,76 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridTemplateActiveVersionBlueGreen(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	resourceName := "sendgrid_template_active_version.new"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTemplateActiveVersionConfig(templateName, "blue", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "active_version_id", "sendgrid_template_version.blue", "id"),
+				),
+			},
+			{
+				Config: testAccCheckSendgridTemplateActiveVersionConfig(templateName, "green", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "active_version_id", "sendgrid_template_version.green", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "previous_version_id", "sendgrid_template_version.blue", "id"),
+				),
+			},
+			{
+				Config: testAccCheckSendgridTemplateActiveVersionConfig(templateName, "green", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "active_version_id", "sendgrid_template_version.blue", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "version_id", "sendgrid_template_version.green", "id"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"version_id", "rollback", "previous_version_id"},
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridTemplateActiveVersionConfig(templateName, color string, rollback bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "template" {
+	name       = "%s"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "blue" {
+	name         = "blue"
+	template_id  = sendgrid_template.template.id
+	subject      = "blue"
+	html_content = "<p>blue</p>"
+}
+
+resource "sendgrid_template_version" "green" {
+	name         = "green"
+	template_id  = sendgrid_template.template.id
+	subject      = "green"
+	html_content = "<p>green</p>"
+}
+
+resource "sendgrid_template_active_version" "new" {
+	template_id = sendgrid_template.template.id
+	version_id  = sendgrid_template_version.%s.id
+	rollback    = %t
+}
+`, templateName, color, rollback)
+}
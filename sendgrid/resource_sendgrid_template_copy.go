@@ -0,0 +1,438 @@
+/*
+Provide a resource to copy a template and its versions from a subuser or an account to another.
+Example Usage
+```hcl
+
+	resource "sendgrid_template_copy" "welcome" {
+		source_template_id  = var.staging_welcome_template_id
+		source_on_behalf_of = "staging"
+		target_on_behalf_of = "production"
+	}
+
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"fmt"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSendgridTemplateCopy() *schema.Resource { //nolint:funlen
+	return &schema.Resource{
+		Description: "Copies a transactional template and all its versions from a subuser or an account to another, " +
+			"e.g. to promote a template from staging to production. The copy is kept in sync with the source.",
+		CreateContext: resourceSendgridTemplateCopyCreate,
+		ReadContext:   resourceSendgridTemplateCopyRead,
+		UpdateContext: resourceSendgridTemplateCopyUpdate,
+		DeleteContext: resourceSendgridTemplateCopyDelete,
+		CustomizeDiff: resourceSendgridTemplateCopyCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"source_template_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the template to copy.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source_on_behalf_of": {
+				Type:        schema.TypeString,
+				Description: "The subuser owning the source template. The subuser of the provider by default.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"source_api_key_wo": {
+				Type: schema.TypeString,
+				Description: "An API key of the account owning the source template, " +
+					"to copy a template from another account. The API key of the provider by default. " +
+					"This value is write-only and never stored in the state.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"target_on_behalf_of": {
+				Type:        schema.TypeString,
+				Description: "The subuser receiving the copy. The subuser of the provider by default.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the copy. The name of the source template by default.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(1, maxStringLength),
+			},
+			"keep_in_sync": {
+				Type: schema.TypeBool,
+				Description: "If true (default), the versions created, edited or deleted in the source template " +
+					"since the last apply show up in the plan and are copied again. If false, the template is copied once.",
+				Optional: true,
+				Default:  true,
+			},
+			"generation": {
+				Type:        schema.TypeString,
+				Description: "The generation of the template, copied from the source template.",
+				Computed:    true,
+			},
+			"version_ids": {
+				Type:        schema.TypeMap,
+				Description: "The IDs of the versions of the copy, by ID of the version of the source template.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"active_version_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the active version of the copy, the copy of the active version of the source.",
+				Computed:    true,
+			},
+			"source_versions": {
+				Type: schema.TypeMap,
+				Description: "The last update date of the versions of the source template when they were copied, " +
+					"by ID of the version of the source template.",
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// templateCopySourceClient returns the client reading the source template,
+// which may belong to another account than the one of the provider.
+func templateCopySourceClient(d templateCopyGetter, m interface{}) (*sendgrid.Client, error) {
+	config := m.(*Config)
+	onBehalfOf := d.Get("source_on_behalf_of").(string)
+
+	apiKey, err := writeOnlyString(d, "source_api_key_wo", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey == "" {
+		return config.NewClient(onBehalfOf), nil
+	}
+
+	return sendgrid.NewClient(apiKey, config.Host, onBehalfOf), nil
+}
+
+// templateCopyGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type templateCopyGetter interface {
+	writeOnlyGetter
+	Get(key string) interface{}
+}
+
+func resourceSendgridTemplateCopyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	target := config.NewClient(d.Get("target_on_behalf_of").(string))
+
+	source, err := templateCopySourceClient(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sourceStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return source.ReadTemplate(ctx, d.Get("source_template_id").(string))
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sourceTemplate := sourceStruct.(*sendgrid.Template)
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name = sourceTemplate.Name
+	}
+
+	templateStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return target.CreateTemplate(ctx, name, sourceTemplate.Generation)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(templateStruct.(*sendgrid.Template).ID)
+
+	if err := syncTemplateCopyVersions(ctx, d, source, target, sourceTemplate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSendgridTemplateCopyRead(ctx, d, m)
+}
+
+func resourceSendgridTemplateCopyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	target := config.NewClient(d.Get("target_on_behalf_of").(string))
+
+	templateStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return target.ReadTemplate(ctx, d.Id())
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	template := templateStruct.(*sendgrid.Template)
+
+	targetVersionIDs := map[string]bool{}
+	activeVersionID := ""
+
+	for _, version := range template.Versions {
+		targetVersionIDs[version.ID] = true
+
+		if version.Active == 1 {
+			activeVersionID = version.ID
+		}
+	}
+
+	// A version deleted from the copy is dropped from the mapping, so that the next apply copies it again.
+	versionIDs := map[string]interface{}{}
+	sourceVersions := d.Get("source_versions").(map[string]interface{})
+
+	for sourceID, targetID := range d.Get("version_ids").(map[string]interface{}) {
+		if targetVersionIDs[targetID.(string)] {
+			versionIDs[sourceID] = targetID
+		} else {
+			delete(sourceVersions, sourceID)
+		}
+	}
+
+	//nolint:errcheck
+	d.Set("name", template.Name)
+	//nolint:errcheck
+	d.Set("generation", template.Generation)
+	//nolint:errcheck
+	d.Set("version_ids", versionIDs)
+	//nolint:errcheck
+	d.Set("source_versions", sourceVersions)
+	//nolint:errcheck
+	d.Set("active_version_id", activeVersionID)
+
+	return nil
+}
+
+func resourceSendgridTemplateCopyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	target := config.NewClient(d.Get("target_on_behalf_of").(string))
+
+	if d.HasChange("name") {
+		_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return target.UpdateTemplate(ctx, d.Id(), d.Get("name").(string))
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.Get("keep_in_sync").(bool) {
+		source, err := templateCopySourceClient(d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		sourceStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return source.ReadTemplate(ctx, d.Get("source_template_id").(string))
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := syncTemplateCopyVersions(ctx, d, source, target, sourceStruct.(*sendgrid.Template)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSendgridTemplateCopyRead(ctx, d, m)
+}
+
+func resourceSendgridTemplateCopyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	target := config.NewClient(d.Get("target_on_behalf_of").(string))
+
+	_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return target.DeleteTemplate(ctx, d.Id())
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceSendgridTemplateCopyCustomizeDiff reads the source template at plan time,
+// so that the versions changed in the source since the last apply show up in the plan.
+func resourceSendgridTemplateCopyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.Get("keep_in_sync").(bool) || !diff.NewValueKnown("source_template_id") {
+		return nil
+	}
+
+	// An API key known at apply time only can't be used to read the source template yet.
+	if apiKey, _ := diff.GetRawConfigAt(cty.GetAttrPath("source_api_key_wo")); !apiKey.IsKnown() {
+		return nil
+	}
+
+	source, err := templateCopySourceClient(diff, m)
+	if err != nil {
+		return err
+	}
+
+	sourceStruct, err := sendgrid.RetryOnRateLimit(ctx, &schema.ResourceData{}, func() (interface{}, sendgrid.RequestError) {
+		return source.ReadTemplate(ctx, diff.Get("source_template_id").(string))
+	})
+	if err != nil {
+		return err
+	}
+
+	sourceTemplate := sourceStruct.(*sendgrid.Template)
+
+	copies, deletes := templateCopyChanges(sourceTemplate.Versions,
+		diff.Get("version_ids").(map[string]interface{}), diff.Get("source_versions").(map[string]interface{}))
+	if len(copies) == 0 && len(deletes) == 0 &&
+		diff.Get("active_version_id").(string) ==
+			templateCopyActiveVersionID(sourceTemplate, diff.Get("version_ids").(map[string]interface{})) {
+		return nil
+	}
+
+	for _, key := range []string{"source_versions", "version_ids", "active_version_id"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// templateCopyActiveVersionID returns the ID of the copy of the active version of the source template.
+func templateCopyActiveVersionID(sourceTemplate *sendgrid.Template, versionIDs map[string]interface{}) string {
+	for _, version := range sourceTemplate.Versions {
+		if version.Active == 1 {
+			if targetID, ok := versionIDs[version.ID]; ok {
+				return targetID.(string)
+			}
+		}
+	}
+
+	return ""
+}
+
+// templateCopyChanges returns the IDs of the source versions to copy, because they weren't copied yet
+// or were updated since, and the IDs of the source versions deleted since they were copied.
+func templateCopyChanges(
+	sourceVersions []sendgrid.TemplateVersion,
+	versionIDs, copiedVersions map[string]interface{},
+) ([]string, []string) {
+	copies := []string{}
+	existing := map[string]bool{}
+
+	for _, version := range sourceVersions {
+		existing[version.ID] = true
+
+		if _, ok := versionIDs[version.ID]; !ok || copiedVersions[version.ID] != version.UpdatedAt {
+			copies = append(copies, version.ID)
+		}
+	}
+
+	deletes := []string{}
+
+	for sourceID := range versionIDs {
+		if !existing[sourceID] {
+			deletes = append(deletes, sourceID)
+		}
+	}
+
+	return copies, deletes
+}
+
+// syncTemplateCopyVersions copies the versions of the source template to the copy, activates the copy
+// of the active version, then deletes the copies of the versions deleted from the source template.
+func syncTemplateCopyVersions(
+	ctx context.Context,
+	d *schema.ResourceData,
+	source, target *sendgrid.Client,
+	sourceTemplate *sendgrid.Template,
+) error {
+	// The mapping is planned as unknown when the source changed, so it's read from the prior state.
+	oldVersionIDs, _ := d.GetChange("version_ids")
+	oldCopiedVersions, _ := d.GetChange("source_versions")
+	versionIDs := oldVersionIDs.(map[string]interface{})
+	copiedVersions := oldCopiedVersions.(map[string]interface{})
+
+	copies, deletes := templateCopyChanges(sourceTemplate.Versions, versionIDs, copiedVersions)
+
+	// The mapping is saved even when a call fails, so that the versions already copied aren't copied twice.
+	defer func() {
+		//nolint:errcheck
+		d.Set("version_ids", versionIDs)
+		//nolint:errcheck
+		d.Set("source_versions", copiedVersions)
+	}()
+
+	for _, sourceID := range copies {
+		versionStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return source.ReadTemplateVersion(ctx, sourceTemplate.ID, sourceID)
+		})
+		if err != nil {
+			return err
+		}
+
+		version := versionStruct.(*sendgrid.TemplateVersion)
+		templateVersion := sendgrid.TemplateVersion{
+			TemplateID:           d.Id(),
+			Name:                 version.Name,
+			Subject:              version.Subject,
+			HTMLContent:          version.HTMLContent,
+			PlainContent:         version.PlainContent,
+			GeneratePlainContent: false,
+			Editor:               version.Editor,
+			TestData:             version.TestData,
+		}
+
+		targetID, ok := versionIDs[sourceID].(string)
+
+		copyStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			if ok {
+				templateVersion.ID = targetID
+
+				return target.UpdateTemplateVersion(ctx, templateVersion)
+			}
+
+			return target.CreateTemplateVersion(ctx, templateVersion)
+		})
+		if err != nil {
+			return fmt.Errorf("failed copying version %q: %w", sourceID, err)
+		}
+
+		versionIDs[sourceID] = copyStruct.(*sendgrid.TemplateVersion).ID
+		copiedVersions[sourceID] = version.UpdatedAt
+	}
+
+	if activeVersionID := templateCopyActiveVersionID(sourceTemplate, versionIDs); activeVersionID != "" {
+		_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return target.ActivateTemplateVersion(ctx, sendgrid.TemplateVersion{ID: activeVersionID, TemplateID: d.Id()})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sourceID := range deletes {
+		targetID := versionIDs[sourceID].(string)
+
+		_, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return target.DeleteTemplateVersion(ctx, d.Id(), targetID)
+		})
+		if err != nil {
+			return err
+		}
+
+		delete(versionIDs, sourceID)
+		delete(copiedVersions, sourceID)
+	}
+
+	return nil
+}
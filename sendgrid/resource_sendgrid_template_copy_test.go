@@ -0,0 +1,112 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridTemplateCopy(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	resourceName := "sendgrid_template_copy.new"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTemplateCopyConfig(templateName, "green"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", templateName+"-copy"),
+					resource.TestCheckResourceAttr(resourceName, "generation", "dynamic"),
+					resource.TestCheckResourceAttr(resourceName, "version_ids.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "source_versions.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "active_version_id"),
+				),
+			},
+			{
+				// The subject of the source version changed: the copy is updated, not re-created.
+				Config: testAccCheckSendgridTemplateCopyConfig(templateName, "green v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version_ids.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSendgridTemplateCopySourceAPIKey(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	resourceName := "sendgrid_template_copy.new"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The API key of the provider stands for the one of another account.
+				Config: testAccCheckSendgridTemplateCopyConfigSourceAPIKey(templateName, os.Getenv("SENDGRID_API_KEY")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version_ids.%", "1"),
+					resource.TestCheckNoResourceAttr(resourceName, "source_api_key_wo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridTemplateCopyConfig(templateName, greenSubject string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "template" {
+	name       = "%s"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "blue" {
+	name         = "blue"
+	template_id  = sendgrid_template.template.id
+	subject      = "blue"
+	html_content = "<p>blue</p>"
+}
+
+resource "sendgrid_template_version" "green" {
+	name         = "green"
+	template_id  = sendgrid_template.template.id
+	subject      = "%s"
+	html_content = "<p>green</p>"
+	active       = 1
+}
+
+resource "sendgrid_template_copy" "new" {
+	depends_on         = [sendgrid_template_version.blue, sendgrid_template_version.green]
+	source_template_id = sendgrid_template.template.id
+	name               = "${sendgrid_template.template.name}-copy"
+}
+`, templateName, greenSubject)
+}
+
+func testAccCheckSendgridTemplateCopyConfigSourceAPIKey(templateName, apiKey string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "template" {
+	name       = "%s"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "version" {
+	name         = "version"
+	template_id  = sendgrid_template.template.id
+	subject      = "subject"
+	html_content = "<p>content</p>"
+}
+
+resource "sendgrid_template_copy" "new" {
+	depends_on         = [sendgrid_template_version.version]
+	source_template_id = sendgrid_template.template.id
+	source_api_key_wo  = "%s"
+	name               = "${sendgrid_template.template.name}-copy"
+}
+`, templateName, apiKey)
+}
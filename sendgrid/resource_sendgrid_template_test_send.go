@@ -0,0 +1,269 @@
+/*
+Provide a resource to send a version of a template to a seed list.
+Example Usage
+```hcl
+
+	resource "sendgrid_template_test_send" "welcome" {
+		template_id = sendgrid_template.welcome.id
+		version_id  = sendgrid_template_version.welcome_v2.id
+		to          = ["seed@example.com"]
+		from_email  = "test@example.com"
+
+		triggers = {
+			content = sendgrid_template_version.welcome_v2.content_sha256
+		}
+	}
+
+```
+*/
+package sendgrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/handlebars"
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSendgridTemplateTestSend() *schema.Resource { //nolint:funlen
+	return &schema.Resource{
+		Description: "Sends a version of a template, rendered with its test data, to a seed list. " +
+			"The email is sent when the resource is created, and again each time one of its arguments changes, " +
+			"e.g. one of the `triggers`.",
+		CreateContext: resourceSendgridTemplateTestSendCreate,
+		ReadContext:   resourceSendgridTemplateTestSendRead,
+		DeleteContext: resourceSendgridTemplateTestSendDelete,
+
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the transactional template.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the version to send. The active version of the template by default.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"to": {
+				Type:        schema.TypeSet,
+				Description: "The seed list: the addresses receiving the email, each one in its own email.",
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"from_email": {
+				Type:         schema.TypeString,
+				Description:  "The address of the sender, which must be a verified sender or belong to an authenticated domain.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"from_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the sender.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"test_data": {
+				Type:         schema.TypeString,
+				Description:  "The JSON data the version is rendered with. The test_data of the version by default.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"categories": {
+				Type:        schema.TypeSet,
+				Description: "The categories of the email, to find the test sends in the statistics and the activity feed.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sandbox_mode": {
+				Type: schema.TypeBool,
+				Description: "If true, SendGrid validates the email without delivering it, " +
+					"e.g. to check in CI that a version renders and is accepted.",
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values whose changes send the email again.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sent_version_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the version which was sent.",
+				Computed:    true,
+			},
+			"rendered_by": {
+				Type: schema.TypeString,
+				Description: "sendgrid when the active version of a dynamic template was sent by its template ID, " +
+					"provider when another version was rendered by the provider, like sendgrid_template_render, " +
+					"since SendGrid only sends the active version of a template.",
+				Computed: true,
+			},
+			"message_ids": {
+				Type:        schema.TypeMap,
+				Description: "The ID of the message sent to each address, empty in sandbox mode.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sent_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time the email was sent, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSendgridTemplateTestSendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	templateID := d.Get("template_id").(string)
+
+	version, err := readTemplateVersionToRender(ctx, d, m, templateID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	testData := version.TestData
+	if v, ok := d.GetOk("test_data"); ok {
+		testData = v.(string)
+	}
+
+	mail, renderedBy, err := templateTestSendMail(templateID, version, testData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mail.From = sendgrid.MailAddress{Email: d.Get("from_email").(string), Name: d.Get("from_name").(string)}
+	mail.CustomArgs = map[string]string{"template_version_id": version.ID}
+
+	for _, category := range d.Get("categories").(*schema.Set).List() {
+		mail.Categories = append(mail.Categories, category.(string))
+	}
+
+	if d.Get("sandbox_mode").(bool) {
+		mail.MailSettings = &sendgrid.MailSettings{SandboxMode: &sendgrid.MailSetting{Enable: true}}
+	}
+
+	// Each address gets its own email, so that the seeds don't see each other.
+	personalization := mail.Personalizations[0]
+	messageIDs := map[string]interface{}{}
+
+	for _, to := range d.Get("to").(*schema.Set).List() {
+		personalization.To = []sendgrid.MailAddress{{Email: to.(string)}}
+		mail.Personalizations = []sendgrid.MailPersonalization{personalization}
+
+		messageID, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+			return c.SendMail(ctx, mail)
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed sending version %q to %s: %w", version.ID, to, err))
+		}
+
+		messageIDs[to.(string)] = messageID.(string)
+	}
+
+	sentAt := time.Now().UTC().Format(time.RFC3339)
+
+	d.SetId(version.ID + "/" + sentAt)
+	//nolint:errcheck
+	d.Set("sent_version_id", version.ID)
+	//nolint:errcheck
+	d.Set("rendered_by", renderedBy)
+	//nolint:errcheck
+	d.Set("message_ids", messageIDs)
+	//nolint:errcheck
+	d.Set("sent_at", sentAt)
+
+	return nil
+}
+
+// resourceSendgridTemplateTestSendRead does nothing: a sent email can't change.
+func resourceSendgridTemplateTestSendRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceSendgridTemplateTestSendDelete only removes the resource from the state: a sent email can't be recalled.
+func resourceSendgridTemplateTestSendDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// templateTestSendMail returns the email of a test send, without its sender and recipient,
+// and who renders it. SendGrid only sends the active version of a dynamic template,
+// the other versions are rendered by the provider and sent as content.
+func templateTestSendMail(
+	templateID string,
+	version *sendgrid.TemplateVersion,
+	testData string,
+) (sendgrid.Mail, string, error) {
+	if testData == "" {
+		testData = "{}"
+	}
+
+	if version.Active == 1 && strings.HasPrefix(templateID, "d-") {
+		return sendgrid.Mail{
+			TemplateID: templateID,
+			Personalizations: []sendgrid.MailPersonalization{{
+				DynamicTemplateData: json.RawMessage(testData),
+			}},
+		}, "sendgrid", nil
+	}
+
+	data, err := handlebars.ParseData(testData)
+	if err != nil {
+		return sendgrid.Mail{}, "", err
+	}
+
+	mail := sendgrid.Mail{Personalizations: []sendgrid.MailPersonalization{{}}}
+
+	for _, content := range []struct {
+		name, mimeType, src string
+	}{
+		{name: "subject", src: version.Subject},
+		{name: "plain_content", mimeType: "text/plain", src: version.PlainContent},
+		{name: "html_content", mimeType: "text/html", src: version.HTMLContent},
+	} {
+		rendered, err := handlebars.Render(content.src, data)
+		if err != nil {
+			return sendgrid.Mail{}, "", fmt.Errorf("could not render %s:\n%w", content.name, err)
+		}
+
+		switch {
+		case content.mimeType == "":
+			mail.Subject = rendered
+		case rendered != "":
+			// SendGrid requires the plain content to come before the HTML content.
+			mail.Content = append(mail.Content, sendgrid.MailContent{Type: content.mimeType, Value: rendered})
+		}
+	}
+
+	return mail, "provider", nil
+}
@@ -0,0 +1,76 @@
+package sendgrid_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSendgridTemplateTestSendSandbox(t *testing.T) {
+	fromEmail := os.Getenv("SENDGRID_TEST_FROM_EMAIL")
+	if fromEmail == "" {
+		t.Skip("SENDGRID_TEST_FROM_EMAIL must be a verified sender to send test emails")
+	}
+
+	templateName := "terraform-template-" + acctest.RandString(10)
+	resourceName := "sendgrid_template_test_send.new"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTemplateTestSendConfig(templateName, fromEmail, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "sent_version_id", "sendgrid_template_version.blue", "id"),
+					resource.TestCheckResourceAttr(resourceName, "rendered_by", "sendgrid"),
+					resource.TestCheckResourceAttrSet(resourceName, "sent_at"),
+				),
+			},
+			{
+				Config: testAccCheckSendgridTemplateTestSendConfig(templateName, fromEmail, "green"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "sent_version_id", "sendgrid_template_version.green", "id"),
+					resource.TestCheckResourceAttr(resourceName, "rendered_by", "provider"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSendgridTemplateTestSendConfig(templateName, fromEmail, color string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "template" {
+	name       = "%s"
+	generation = "dynamic"
+}
+
+resource "sendgrid_template_version" "blue" {
+	name         = "blue"
+	template_id  = sendgrid_template.template.id
+	subject      = "Hello {{first_name}}"
+	html_content = "<p>blue</p>"
+	active       = 1
+	test_data    = jsonencode({ first_name = "Ada" })
+}
+
+resource "sendgrid_template_version" "green" {
+	name         = "green"
+	template_id  = sendgrid_template.template.id
+	subject      = "Hello {{first_name}}"
+	html_content = "<p>green</p>"
+	depends_on   = [sendgrid_template_version.blue]
+}
+
+resource "sendgrid_template_test_send" "new" {
+	template_id  = sendgrid_template.template.id
+	version_id   = sendgrid_template_version.%s.id
+	to           = ["seed@example.com"]
+	from_email   = "%s"
+	sandbox_mode = true
+}
+`, templateName, color, fromEmail)
+}
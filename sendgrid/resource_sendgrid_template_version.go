@@ -17,6 +17,15 @@ Example Usage
 		subject                = "subject"
 	}
 
+	resource "sendgrid_template_version" "from_files" {
+		name                   = "my-template-version-from-files"
+		template_id            = sendgrid_template.template.id
+		html_content_file      = "${path.module}/templates/welcome.html"
+		plain_content_file     = "${path.module}/templates/welcome.txt"
+		generate_plain_content = false
+		subject                = "subject"
+	}
+
 ```
 Import
 A template version can be imported, e.g.
@@ -28,10 +37,19 @@ package sendgrid
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"reflect"
 	"strings"
 
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/handlebars"
+	"github.com/arslanbekov/terraform-provider-sendgrid/internal/plaintext"
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -50,6 +68,7 @@ func resourceSendgridTemplateVersion() *schema.Resource { //nolint:funlen
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSendgridTemplateVersionImport,
 		},
+		CustomizeDiff: resourceSendgridTemplateVersionCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"template_id": {
@@ -71,7 +90,8 @@ func resourceSendgridTemplateVersion() *schema.Resource { //nolint:funlen
 				Type: schema.TypeInt,
 				Description: "Set the version as the active version associated with the template. " +
 					"Only one version of a template can be active. " +
-					"The first version created for a template will automatically be set to Active. Allowed values: 0, 1.",
+					"The first version created for a template will automatically be set to Active. Allowed values: 0, 1. " +
+					"Leave it unset when the template is managed by `sendgrid_template_active_version`.",
 				Optional: true,
 			},
 			"name": {
@@ -80,19 +100,53 @@ func resourceSendgridTemplateVersion() *schema.Resource { //nolint:funlen
 				Required:    true,
 			},
 			"html_content": {
-				Type:        schema.TypeString,
-				Description: "The HTML content of the version, maximum of 1048576 bytes allowed.",
-				Optional:    true,
+				Type: schema.TypeString,
+				Description: "The HTML content of the version, maximum of 1048576 bytes allowed. " +
+					"Leave it unset with `editor = \"design\"` to keep the content edited in the Design Library.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"html_content_file", "design_id"},
+			},
+			"html_content_file": {
+				Type: schema.TypeString,
+				Description: "Path to a file holding the HTML content of the version. " +
+					"The content is not stored in the state, changes are detected with `content_sha256`.",
+				Optional:      true,
+				ConflictsWith: []string{"html_content", "design_id"},
 			},
 			"plain_content": {
-				Type:        schema.TypeString,
-				Description: "Text/plain content of the transactional template version, maximum of 1048576 bytes allowed.",
-				Computed:    true,
-				Optional:    true,
+				Type: schema.TypeString,
+				Description: "Text/plain content of the transactional template version, maximum of 1048576 bytes allowed, " +
+					"requires `generate_plain_content` to be false.",
+				Computed:      true,
+				Optional:      true,
+				ConflictsWith: []string{"plain_content_file", "design_id"},
+			},
+			"plain_content_file": {
+				Type: schema.TypeString,
+				Description: "Path to a file holding the plain content of the version, " +
+					"requires `generate_plain_content` to be false. " +
+					"The content is not stored in the state, changes are detected with `content_sha256`.",
+				Optional:      true,
+				ConflictsWith: []string{"plain_content", "design_id"},
+			},
+			"design_id": {
+				Type: schema.TypeString,
+				Description: "ID of a design of the Design Library whose content is duplicated into the version. " +
+					"The design is read at plan time: its changes show up in the plan and are duplicated again.",
+				Optional: true,
+			},
+			"content_sha256": {
+				Type: schema.TypeString,
+				Description: "SHA-256 of the HTML content, followed by a NUL byte and the plain content when `plain_content_file` is set. " +
+					"Edits made outside of Terraform show up as a change of this hash.",
+				Computed: true,
 			},
 			"generate_plain_content": {
 				Type: schema.TypeBool,
-				Description: "If true (default), plain_content is always generated from html_content. " +
+				Description: "If true (default), plain_content is generated from html_content by the provider: " +
+					"tags are stripped, links are kept as `text (url)` and Handlebars expressions are kept as they are. " +
+					"The generation is deterministic, so the plan shows the plain content before it is applied. " +
 					"If false, plain_content is not altered.",
 				Optional: true,
 				Default:  true,
@@ -109,11 +163,22 @@ func resourceSendgridTemplateVersion() *schema.Resource { //nolint:funlen
 				Default:      "code",
 				ValidateFunc: validation.StringInSlice([]string{"code", "design"}, false),
 			},
+			"validate_handlebars": {
+				Type: schema.TypeBool,
+				Description: "Check the Handlebars syntax of the subject and the content at plan time: " +
+					"unbalanced blocks and helpers SendGrid doesn't support are reported with their line and column. " +
+					"Defaults to `true`, disable it for the versions of legacy templates, whose content may contain literal `{{`.",
+				Optional: true,
+				Default:  true,
+			},
 			"test_data": {
 				Type: schema.TypeString,
 				Description: "For dynamic templates only, " +
-					"the mock json data that will be used for template preview and test sends.",
-				Optional: true,
+					"the mock json data that will be used for template preview and test sends. " +
+					"It is also the default data of the `sendgrid_template_render` data source.",
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
 			},
 		},
 	}
@@ -123,14 +188,24 @@ func resourceSendgridTemplateVersionCreate(ctx context.Context, d *schema.Resour
 	config := m.(*Config)
 	c := config.NewClient("")
 
+	get, er := templateVersionDesignContent(ctx, d, m, d.Get)
+	if er != nil {
+		return diag.FromErr(er)
+	}
+
+	contents, er := templateVersionContents(get, templateVersionHTMLConfigured(d.GetRawConfig()))
+	if er != nil {
+		return diag.FromErr(er)
+	}
+
 	templateVersionStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
 		return c.CreateTemplateVersion(ctx, sendgrid.TemplateVersion{
 			TemplateID:           d.Get("template_id").(string),
 			Active:               d.Get("active").(int),
 			Name:                 d.Get("name").(string),
-			HTMLContent:          d.Get("html_content").(string),
-			PlainContent:         d.Get("plain_content").(string),
-			GeneratePlainContent: d.Get("generate_plain_content").(bool),
+			HTMLContent:          contents.html,
+			PlainContent:         contents.plain,
+			GeneratePlainContent: contents.generatedBySendGrid,
 			Subject:              d.Get("subject").(string),
 			Editor:               d.Get("editor").(string),
 			TestData:             d.Get("test_data").(string),
@@ -160,10 +235,31 @@ func resourceSendgridTemplateVersionRead(ctx context.Context, d *schema.Resource
 	}
 
 	templateVersion := templateVersionStruct.(*sendgrid.TemplateVersion)
+	wasActive := d.Get("active").(int) == 1
+
+	// The plain content is sent already generated, so SendGrid always reports generate_plain_content = false.
+	generatePlainContent := d.Get("generate_plain_content").(bool)
+
 	if er := parseTemplateVersion(d, templateVersion); er != nil {
 		return diag.FromErr(er)
 	}
 
+	if er := d.Set("generate_plain_content", generatePlainContent); er != nil {
+		return diag.FromErr(ErrSetTemplateVersionGenPlainContent)
+	}
+
+	if wasActive && templateVersion.Active != 1 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Template version deactivated by another version",
+			Detail: fmt.Sprintf("Version %q of template %q isn't active anymore. "+
+				"When several versions set active = 1 only the last activated one stays active, "+
+				"and each apply activates them again: "+
+				"manage the active version with sendgrid_template_active_version instead.",
+				d.Id(), templateVersion.TemplateID),
+		}}
+	}
+
 	return nil
 }
 
@@ -184,14 +280,32 @@ func parseTemplateVersion(d *schema.ResourceData, templateVersion *sendgrid.Temp
 		return ErrSetTemplateVersionName
 	}
 
-	if err := d.Set("html_content", templateVersion.HTMLContent); err != nil {
+	// Content loaded from files is only tracked through its hash, to keep it out of the state.
+	htmlContent := templateVersion.HTMLContent
+	if d.Get("html_content_file").(string) != "" {
+		htmlContent = ""
+	}
+
+	if err := d.Set("html_content", htmlContent); err != nil {
 		return ErrSetTemplateVersionHTMLContent
 	}
 
-	if err := d.Set("plain_content", templateVersion.PlainContent); err != nil {
+	plainContentFile := d.Get("plain_content_file").(string) != ""
+
+	plainContent := templateVersion.PlainContent
+	if plainContentFile {
+		plainContent = ""
+	}
+
+	if err := d.Set("plain_content", plainContent); err != nil {
 		return ErrSetTemplateVersionPlainContent
 	}
 
+	contentSHA256 := templateVersionContentSHA256(templateVersion.HTMLContent, templateVersion.PlainContent, plainContentFile)
+	if err := d.Set("content_sha256", contentSHA256); err != nil {
+		return ErrSetTemplateVersionContentSHA256
+	}
+
 	if err := d.Set("generate_plain_content", templateVersion.GeneratePlainContent); err != nil {
 		return ErrSetTemplateVersionGenPlainContent
 	}
@@ -204,6 +318,10 @@ func parseTemplateVersion(d *schema.ResourceData, templateVersion *sendgrid.Temp
 		return ErrSetTemplateVersionEditor
 	}
 
+	if err := d.Set("test_data", templateVersion.TestData); err != nil {
+		return ErrSetTemplateVersionTestData
+	}
+
 	return nil
 }
 
@@ -215,9 +333,20 @@ func resourceSendgridTemplateVersionUpdate(
 	config := m.(*Config)
 	c := config.NewClient("")
 
+	get, er := templateVersionDesignContent(ctx, d, m, d.Get)
+	if er != nil {
+		return diag.FromErr(er)
+	}
+
+	contents, er := templateVersionContents(get, templateVersionHTMLConfigured(d.GetRawConfig()))
+	if er != nil {
+		return diag.FromErr(er)
+	}
+
 	baseTemplateVersion := sendgrid.TemplateVersion{
-		ID:         d.Id(),
-		TemplateID: d.Get("template_id").(string),
+		ID:                   d.Id(),
+		TemplateID:           d.Get("template_id").(string),
+		GeneratePlainContent: contents.generatedBySendGrid,
 	}
 	templateVersion := baseTemplateVersion
 
@@ -238,16 +367,13 @@ func resourceSendgridTemplateVersionUpdate(
 		templateVersion.Name = d.Get("name").(string)
 	}
 
-	if d.HasChange("html_content") {
-		templateVersion.HTMLContent = d.Get("html_content").(string)
-	}
-
-	if d.HasChange("plain_content") {
-		templateVersion.PlainContent = d.Get("plain_content").(string)
+	if d.HasChanges("html_content", "html_content_file", "design_id", "content_sha256") && !contents.generatedBySendGrid {
+		templateVersion.HTMLContent = contents.html
 	}
 
-	if d.HasChange("generate_plain_content") {
-		templateVersion.GeneratePlainContent = d.Get("generate_plain_content").(bool)
+	if d.HasChanges("plain_content", "plain_content_file", "generate_plain_content", "design_id") ||
+		(d.Get("plain_content_file").(string) != "" && d.HasChange("content_sha256")) {
+		templateVersion.PlainContent = contents.plain
 	}
 
 	if d.HasChange("subject") {
@@ -293,16 +419,263 @@ func resourceSendgridTemplateVersionDelete(ctx context.Context, d *schema.Resour
 func resourceSendgridTemplateVersionImport(
 	ctx context.Context,
 	d *schema.ResourceData,
-	_ interface{},
+	m interface{},
 ) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
 	if len(parts) != ImportSplitParts {
 		return nil, ErrInvalidImportFormat
 	}
 
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	templateVersionStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadTemplateVersion(ctx, parts[0], parts[1])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The plain content is only generated by the provider when it's the one of the version already,
+	// so that importing a version written by hand doesn't plan to replace its plain content.
+	templateVersion := templateVersionStruct.(*sendgrid.TemplateVersion)
+
 	//nolint:errcheck
 	d.Set("template_id", parts[0])
+	//nolint:errcheck
+	d.Set("generate_plain_content", templateVersion.PlainContent == plaintext.FromHTML(templateVersion.HTMLContent))
 	d.SetId(parts[1])
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// resourceSendgridTemplateVersionCustomizeDiff plans the hash of the local content,
+// so that changes of the files and edits made outside of Terraform show up as a hash diff.
+func resourceSendgridTemplateVersionCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Get("validate_handlebars").(bool) {
+		if err := validateTemplateVersionHandlebars(diff); err != nil {
+			return err
+		}
+	}
+
+	generatePlainContent := diff.Get("generate_plain_content").(bool)
+	plainContentFile := diff.Get("plain_content_file").(string) != ""
+
+	if generatePlainContent && (plainContentFile || !diff.GetRawConfig().GetAttr("plain_content").IsNull()) {
+		return ErrTemplateVersionGeneratedPlainContent
+	}
+
+	for _, key := range []string{
+		"html_content", "html_content_file", "plain_content", "plain_content_file", "editor", "design_id",
+	} {
+		if diff.NewValueKnown(key) {
+			continue
+		}
+
+		if generatePlainContent {
+			if err := diff.SetNewComputed("plain_content"); err != nil {
+				return err
+			}
+		}
+
+		return diff.SetNewComputed("content_sha256")
+	}
+
+	// The design is read at plan time, with the default timeout.
+	get, err := templateVersionDesignContent(ctx, &schema.ResourceData{}, m, diff.Get)
+	if err != nil {
+		return err
+	}
+
+	contents, err := templateVersionContents(get, templateVersionHTMLConfigured(diff.GetRawConfig()))
+	if err != nil {
+		return err
+	}
+
+	fromDesign := diff.Get("design_id").(string) != ""
+
+	if fromDesign && diff.Get("html_content").(string) != contents.html {
+		if err := diff.SetNew("html_content", contents.html); err != nil {
+			return err
+		}
+	}
+
+	if (fromDesign || generatePlainContent) && !contents.generatedBySendGrid &&
+		diff.Get("plain_content").(string) != contents.plain {
+		if err := diff.SetNew("plain_content", contents.plain); err != nil {
+			return err
+		}
+	}
+
+	// The content of the versions edited in the Design Library is the one read from SendGrid.
+	if contents.generatedBySendGrid {
+		return nil
+	}
+
+	contentSHA256 := templateVersionContentSHA256(contents.html, contents.plain, plainContentFile)
+	if diff.Get("content_sha256").(string) != contentSHA256 {
+		return diff.SetNew("content_sha256", contentSHA256)
+	}
+
+	return nil
+}
+
+// validateTemplateVersionHandlebars parses the known subject and content, without any API call.
+func validateTemplateVersionHandlebars(diff *schema.ResourceDiff) error {
+	var errs []error
+
+	for _, key := range []string{"subject", "html_content", "plain_content"} {
+		argument := key
+		if key != "subject" && diff.Get(key+"_file").(string) != "" {
+			argument = key + "_file"
+		}
+
+		// Only the configuration is checked, not the content generated by SendGrid.
+		if !diff.NewValueKnown(argument) || diff.GetRawConfig().GetAttr(argument).IsNull() {
+			continue
+		}
+
+		content := diff.Get(key).(string)
+
+		if argument != key {
+			var err error
+			if content, err = templateVersionContent(diff.Get, key); err != nil {
+				return err
+			}
+		}
+
+		if err := handlebars.Validate(content); err != nil {
+			errs = append(errs, fmt.Errorf("invalid Handlebars in %s:\n%w", argument, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// templateVersionContent returns the content of the <key>_file argument when set, and the <key> argument otherwise.
+func templateVersionContent(get func(string) interface{}, key string) (string, error) {
+	path := get(key + "_file").(string)
+	if path == "" {
+		return get(key).(string), nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read %s_file: %w", key, err)
+	}
+
+	return string(content), nil
+}
+
+// templateVersionDesignContent returns get, with the content of the design given by design_id in place of the
+// content arguments, so that the design is duplicated into the version.
+func templateVersionDesignContent(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+	get func(string) interface{},
+) (func(string) interface{}, error) {
+	designID := get("design_id").(string)
+	if designID == "" {
+		return get, nil
+	}
+
+	config := m.(*Config)
+	c := config.NewClient("")
+
+	designStruct, err := sendgrid.RetryOnRateLimit(ctx, d, func() (interface{}, sendgrid.RequestError) {
+		return c.ReadDesign(ctx, designID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	design := designStruct.(*sendgrid.Design)
+
+	return func(key string) interface{} {
+		switch key {
+		case "html_content":
+			return design.HTMLContent
+		case "plain_content":
+			return design.PlainContent
+		case "html_content_file", "plain_content_file":
+			return ""
+		}
+
+		return get(key)
+	}, nil
+}
+
+// templateVersionPayload is the content of a version as it is sent to SendGrid.
+type templateVersionPayload struct {
+	html  string
+	plain string
+	// generatedBySendGrid is only set for versions edited in the Design Library,
+	// whose HTML content isn't known by the provider.
+	generatedBySendGrid bool
+}
+
+// templateVersionContents reads the content of the version and generates its plain content when requested.
+// htmlConfigured is false for the versions whose HTML content is read from SendGrid, see templateVersionHTMLConfigured.
+func templateVersionContents(get func(string) interface{}, htmlConfigured bool) (templateVersionPayload, error) {
+	html, err := templateVersionContent(get, "html_content")
+	if err != nil {
+		return templateVersionPayload{}, err
+	}
+
+	if !get("generate_plain_content").(bool) {
+		plain, err := templateVersionContent(get, "plain_content")
+
+		return templateVersionPayload{html: html, plain: plain}, err
+	}
+
+	if !htmlConfigured && get("editor").(string) == "design" {
+		return templateVersionPayload{generatedBySendGrid: true}, nil
+	}
+
+	return templateVersionPayload{html: html, plain: plaintext.FromHTML(html)}, nil
+}
+
+// templateVersionHTMLConfigured returns whether the HTML content is given by the configuration.
+// html_content is computed, so that it holds the HTML read from SendGrid once refreshed: the configuration
+// tells the versions edited in the Design Library apart. It's considered configured when the configuration isn't available.
+func templateVersionHTMLConfigured(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return true
+	}
+
+	for _, key := range []string{"html_content", "html_content_file", "design_id"} {
+		if value := config.GetAttr(key); !value.IsKnown() || !value.IsNull() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func templateVersionContentSHA256(htmlContent, plainContent string, withPlainContent bool) string {
+	hash := sha256.New()
+	hash.Write([]byte(htmlContent))
+
+	// The NUL byte separates the contents, so that moving text from one to the other changes the hash.
+	if withPlainContent {
+		hash.Write([]byte{0})
+		hash.Write([]byte(plainContent))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// suppressEquivalentJSON ignores formatting changes of JSON documents, such as the test data reformatted by SendGrid.
+func suppressEquivalentJSON(_, old, new string, _ *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	var oldValue, newValue interface{}
+	if json.Unmarshal([]byte(old), &oldValue) != nil || json.Unmarshal([]byte(new), &newValue) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
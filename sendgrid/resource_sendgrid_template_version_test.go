@@ -3,6 +3,9 @@ package sendgrid_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
@@ -35,6 +38,116 @@ func TestAccSendgridTemplateVersionBasic(t *testing.T) {
 	})
 }
 
+func TestAccSendgridTemplateVersionContentFiles(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	templateVersionName := "terraform-template-version-" + acctest.RandString(10)
+	dir := t.TempDir()
+	htmlFile := filepath.Join(dir, "content.html")
+	plainFile := filepath.Join(dir, "content.txt")
+
+	writeFiles := func(html, plain string) func() {
+		return func() {
+			if err := os.WriteFile(htmlFile, []byte(html), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(plainFile, []byte(plain), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridTemplateVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeFiles("<p>Hello {{name}}</p>", "Hello {{name}}"),
+				Config:    testAccCheckSendgridTemplateVersionConfigFiles(templateName, templateVersionName, htmlFile, plainFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSendgridTemplateVersionExists("sendgrid_template_version.files"),
+					resource.TestCheckResourceAttr("sendgrid_template_version.files", "html_content", ""),
+					resource.TestCheckResourceAttrSet("sendgrid_template_version.files", "content_sha256"),
+				),
+			},
+			{
+				PreConfig:          writeFiles("<p>Goodbye {{name}}</p>", "Goodbye {{name}}"),
+				Config:             testAccCheckSendgridTemplateVersionConfigFiles(templateName, templateVersionName, htmlFile, plainFile),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccSendgridTemplateVersionInvalidHandlebars(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	templateVersionName := "terraform-template-version-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTemplateVersionConfigHTML(
+					templateName,
+					templateVersionName,
+					"<p>{{#if name}}Hello {{uppercase name}}</p>",
+				),
+				PlanOnly: true,
+				ExpectError: regexp.MustCompile(
+					`(?s)invalid Handlebars in html_content.*line 1, column 4: block "if" is never closed.*` +
+						`line 1, column 16: unknown helper "uppercase"`,
+				),
+			},
+		},
+	})
+}
+
+func TestAccSendgridTemplateVersionLegacyHandlebars(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	templateVersionName := "terraform-template-version-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridTemplateVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTemplateVersionConfigLegacy(templateName, templateVersionName),
+				Check:  testAccCheckSendgridTemplateVersionExists("sendgrid_template_version.legacy"),
+			},
+		},
+	})
+}
+
+func TestAccSendgridTemplateVersionGeneratedPlainContent(t *testing.T) {
+	templateName := "terraform-template-" + acctest.RandString(10)
+	templateVersionName := "terraform-template-version-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSendgridTemplateVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSendgridTemplateVersionConfigHTML(
+					templateName,
+					templateVersionName,
+					`<h1>Hello {{first_name}}</h1><p>Read the <a href="{{url}}">news</a>.</p>`,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"sendgrid_template_version.html", "plain_content", "Hello {{first_name}}\n\nRead the news ({{url}}).",
+					),
+					resource.TestCheckResourceAttr("sendgrid_template_version.html", "generate_plain_content", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSendgridTemplateVersionDestroy(s *terraform.State) error {
 	c := testAccProvider.Meta().(*sendgrid.Client)
 
@@ -72,6 +185,59 @@ func testAccCheckSendgridTemplateVersionConfigBasic(
 	`, templateName, templateVersionName, subject)
 }
 
+func testAccCheckSendgridTemplateVersionConfigFiles(
+	templateName, templateVersionName, htmlFile, plainFile string,
+) string {
+	return fmt.Sprintf(`
+	resource "sendgrid_template" "template" {
+		name       = "%s"
+		generation = "dynamic"
+	}
+	resource "sendgrid_template_version" "files" {
+		template_id            = sendgrid_template.template.id
+		name                   = "%s"
+		subject                = "subject"
+		html_content_file      = "%s"
+		plain_content_file     = "%s"
+		generate_plain_content = false
+	}
+	`, templateName, templateVersionName, htmlFile, plainFile)
+}
+
+func testAccCheckSendgridTemplateVersionConfigHTML(
+	templateName, templateVersionName, htmlContent string,
+) string {
+	return fmt.Sprintf(`
+	resource "sendgrid_template" "template" {
+		name       = "%s"
+		generation = "dynamic"
+	}
+	resource "sendgrid_template_version" "html" {
+		template_id  = sendgrid_template.template.id
+		name         = "%s"
+		subject      = "subject"
+		html_content = %q
+	}
+	`, templateName, templateVersionName, htmlContent)
+}
+
+func testAccCheckSendgridTemplateVersionConfigLegacy(templateName, templateVersionName string) string {
+	return fmt.Sprintf(`
+	resource "sendgrid_template" "template" {
+		name       = "%s"
+		generation = "legacy"
+	}
+	resource "sendgrid_template_version" "legacy" {
+		template_id  = sendgrid_template.template.id
+		name         = "%s"
+		subject      = "<%%subject%%>"
+		html_content = "<p>{{#if not handlebars</p><%%body%%>"
+
+		validate_handlebars = false
+	}
+	`, templateName, templateVersionName)
+}
+
 func testAccCheckSendgridTemplateVersionExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -0,0 +1,659 @@
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// validSendgridScopes is the embedded scope catalog, used when the scopes can't be retrieved
+// from https://api.sendgrid.com/v3/scopes. Retrieved as of 2024.
+var validSendgridScopes = map[string]bool{
+	"access_settings.activity.read":             true,
+	"access_settings.whitelist.create":          true,
+	"access_settings.whitelist.delete":          true,
+	"access_settings.whitelist.read":            true,
+	"access_settings.whitelist.update":          true,
+	"alerts.create":                             true,
+	"alerts.delete":                             true,
+	"alerts.read":                               true,
+	"alerts.update":                             true,
+	"api_keys.create":                           true,
+	"api_keys.delete":                           true,
+	"api_keys.read":                             true,
+	"api_keys.update":                           true,
+	"asm.groups.create":                         true,
+	"asm.groups.delete":                         true,
+	"asm.groups.read":                           true,
+	"asm.groups.suppressions.create":            true,
+	"asm.groups.suppressions.delete":            true,
+	"asm.groups.suppressions.read":              true,
+	"asm.groups.suppressions.update":            true,
+	"asm.groups.update":                         true,
+	"asm.suppressions.global.create":            true,
+	"asm.suppressions.global.delete":            true,
+	"asm.suppressions.global.read":              true,
+	"asm.suppressions.global.update":            true,
+	"billing.create":                            true,
+	"billing.delete":                            true,
+	"billing.read":                              true,
+	"billing.update":                            true,
+	"browsers.stats.read":                       true,
+	"categories.create":                         true,
+	"categories.delete":                         true,
+	"categories.read":                           true,
+	"categories.stats.read":                     true,
+	"categories.stats.sums.read":                true,
+	"categories.update":                         true,
+	"clients.desktop.stats.read":                true,
+	"clients.phone.stats.read":                  true,
+	"clients.stats.read":                        true,
+	"clients.tablet.stats.read":                 true,
+	"clients.webmail.stats.read":                true,
+	"credentials.create":                        true,
+	"credentials.delete":                        true,
+	"credentials.read":                          true,
+	"credentials.update":                        true,
+	"design_library.create":                     true,
+	"design_library.delete":                     true,
+	"design_library.read":                       true,
+	"design_library.update":                     true,
+	"devices.stats.read":                        true,
+	"di.bounce_block_classification.read":       true,
+	"email_testing.read":                        true,
+	"email_testing.write":                       true,
+	"geo.stats.read":                            true,
+	"ips.assigned.read":                         true,
+	"ips.create":                                true,
+	"ips.delete":                                true,
+	"ips.pools.create":                          true,
+	"ips.pools.delete":                          true,
+	"ips.pools.ips.create":                      true,
+	"ips.pools.ips.delete":                      true,
+	"ips.pools.ips.read":                        true,
+	"ips.pools.ips.update":                      true,
+	"ips.pools.read":                            true,
+	"ips.pools.update":                          true,
+	"ips.read":                                  true,
+	"ips.update":                                true,
+	"ips.warmup.create":                         true,
+	"ips.warmup.delete":                         true,
+	"ips.warmup.read":                           true,
+	"ips.warmup.update":                         true,
+	"mail.batch.create":                         true,
+	"mail.batch.delete":                         true,
+	"mail.batch.read":                           true,
+	"mail.batch.update":                         true,
+	"mail.send":                                 true,
+	"mail_settings.address_whitelist.create":    true,
+	"mail_settings.address_whitelist.delete":    true,
+	"mail_settings.address_whitelist.read":      true,
+	"mail_settings.address_whitelist.update":    true,
+	"mail_settings.bcc.create":                  true,
+	"mail_settings.bcc.delete":                  true,
+	"mail_settings.bcc.read":                    true,
+	"mail_settings.bcc.update":                  true,
+	"mail_settings.bounce_purge.create":         true,
+	"mail_settings.bounce_purge.delete":         true,
+	"mail_settings.bounce_purge.read":           true,
+	"mail_settings.bounce_purge.update":         true,
+	"mail_settings.footer.create":               true,
+	"mail_settings.footer.delete":               true,
+	"mail_settings.footer.read":                 true,
+	"mail_settings.footer.update":               true,
+	"mail_settings.forward_bounce.create":       true,
+	"mail_settings.forward_bounce.delete":       true,
+	"mail_settings.forward_bounce.read":         true,
+	"mail_settings.forward_bounce.update":       true,
+	"mail_settings.forward_spam.create":         true,
+	"mail_settings.forward_spam.delete":         true,
+	"mail_settings.forward_spam.read":           true,
+	"mail_settings.forward_spam.update":         true,
+	"mail_settings.plain_content.create":        true,
+	"mail_settings.plain_content.delete":        true,
+	"mail_settings.plain_content.read":          true,
+	"mail_settings.plain_content.update":        true,
+	"mail_settings.read":                        true,
+	"mail_settings.spam_check.create":           true,
+	"mail_settings.spam_check.delete":           true,
+	"mail_settings.spam_check.read":             true,
+	"mail_settings.spam_check.update":           true,
+	"mail_settings.template.create":             true,
+	"mail_settings.template.delete":             true,
+	"mail_settings.template.read":               true,
+	"mail_settings.template.update":             true,
+	"mailbox_providers.stats.read":              true,
+	"marketing.automation.read":                 true,
+	"marketing.read":                            true,
+	"messages.read":                             true,
+	"newsletter.create":                         true,
+	"newsletter.delete":                         true,
+	"newsletter.read":                           true,
+	"newsletter.update":                         true,
+	"partner_settings.new_relic.create":         true,
+	"partner_settings.new_relic.delete":         true,
+	"partner_settings.new_relic.read":           true,
+	"partner_settings.new_relic.update":         true,
+	"partner_settings.read":                     true,
+	"partner_settings.sendwithus.create":        true,
+	"partner_settings.sendwithus.delete":        true,
+	"partner_settings.sendwithus.read":          true,
+	"partner_settings.sendwithus.update":        true,
+	"recipients.erasejob.create":                true,
+	"recipients.erasejob.read":                  true,
+	"sender_verification_eligible":              true,
+	"signup.trigger_confirmation":               true,
+	"sso.settings.create":                       true,
+	"sso.settings.delete":                       true,
+	"sso.settings.read":                         true,
+	"sso.settings.update":                       true,
+	"sso.teammates.create":                      true,
+	"sso.teammates.update":                      true,
+	"stats.global.read":                         true,
+	"stats.read":                                true,
+	"subusers.create":                           true,
+	"subusers.credits.create":                   true,
+	"subusers.credits.delete":                   true,
+	"subusers.credits.read":                     true,
+	"subusers.credits.remaining.create":         true,
+	"subusers.credits.remaining.delete":         true,
+	"subusers.credits.remaining.read":           true,
+	"subusers.credits.remaining.update":         true,
+	"subusers.credits.update":                   true,
+	"subusers.delete":                           true,
+	"subusers.monitor.create":                   true,
+	"subusers.monitor.delete":                   true,
+	"subusers.monitor.read":                     true,
+	"subusers.monitor.update":                   true,
+	"subusers.read":                             true,
+	"subusers.reputations.read":                 true,
+	"subusers.stats.monthly.read":               true,
+	"subusers.stats.read":                       true,
+	"subusers.stats.sums.read":                  true,
+	"subusers.summary.read":                     true,
+	"subusers.update":                           true,
+	"suppression.blocks.create":                 true,
+	"suppression.blocks.delete":                 true,
+	"suppression.blocks.read":                   true,
+	"suppression.blocks.update":                 true,
+	"suppression.bounces.create":                true,
+	"suppression.bounces.delete":                true,
+	"suppression.bounces.read":                  true,
+	"suppression.bounces.update":                true,
+	"suppression.create":                        true,
+	"suppression.delete":                        true,
+	"suppression.invalid_emails.create":         true,
+	"suppression.invalid_emails.delete":         true,
+	"suppression.invalid_emails.read":           true,
+	"suppression.invalid_emails.update":         true,
+	"suppression.read":                          true,
+	"suppression.spam_reports.create":           true,
+	"suppression.spam_reports.delete":           true,
+	"suppression.spam_reports.read":             true,
+	"suppression.spam_reports.update":           true,
+	"suppression.unsubscribes.create":           true,
+	"suppression.unsubscribes.delete":           true,
+	"suppression.unsubscribes.read":             true,
+	"suppression.unsubscribes.update":           true,
+	"suppression.update":                        true,
+	"teammates.create":                          true,
+	"teammates.delete":                          true,
+	"teammates.read":                            true,
+	"teammates.update":                          true,
+	"templates.create":                          true,
+	"templates.delete":                          true,
+	"templates.read":                            true,
+	"templates.update":                          true,
+	"templates.versions.activate.create":        true,
+	"templates.versions.activate.delete":        true,
+	"templates.versions.activate.read":          true,
+	"templates.versions.activate.update":        true,
+	"templates.versions.create":                 true,
+	"templates.versions.delete":                 true,
+	"templates.versions.read":                   true,
+	"templates.versions.update":                 true,
+	"tracking_settings.click.create":            true,
+	"tracking_settings.click.delete":            true,
+	"tracking_settings.click.read":              true,
+	"tracking_settings.click.update":            true,
+	"tracking_settings.google_analytics.create": true,
+	"tracking_settings.google_analytics.delete": true,
+	"tracking_settings.google_analytics.read":   true,
+	"tracking_settings.google_analytics.update": true,
+	"tracking_settings.open.create":             true,
+	"tracking_settings.open.delete":             true,
+	"tracking_settings.open.read":               true,
+	"tracking_settings.open.update":             true,
+	"tracking_settings.read":                    true,
+	"tracking_settings.subscription.create":     true,
+	"tracking_settings.subscription.delete":     true,
+	"tracking_settings.subscription.read":       true,
+	"tracking_settings.subscription.update":     true,
+	"ui.confirm_email":                          true,
+	"ui.provision":                              true,
+	"ui.signup_complete":                        true,
+	"user.account.read":                         true,
+	"user.credits.read":                         true,
+	"user.email.read":                           true,
+	"user.profile.create":                       true,
+	"user.profile.delete":                       true,
+	"user.profile.read":                         true,
+	"user.profile.update":                       true,
+	"user.scheduled_sends.create":               true,
+	"user.scheduled_sends.delete":               true,
+	"user.scheduled_sends.read":                 true,
+	"user.scheduled_sends.update":               true,
+	"user.settings.enforced_tls.read":           true,
+	"user.settings.enforced_tls.update":         true,
+	"user.timezone.create":                      true,
+	"user.timezone.delete":                      true,
+	"user.timezone.read":                        true,
+	"user.timezone.update":                      true,
+	"user.username.read":                        true,
+	"user.webhooks.event.settings.create":       true,
+	"user.webhooks.event.settings.delete":       true,
+	"user.webhooks.event.settings.read":         true,
+	"user.webhooks.event.settings.update":       true,
+	"user.webhooks.event.test.create":           true,
+	"user.webhooks.event.test.delete":           true,
+	"user.webhooks.event.test.read":             true,
+	"user.webhooks.event.test.update":           true,
+	"user.webhooks.parse.settings.create":       true,
+	"user.webhooks.parse.settings.delete":       true,
+	"user.webhooks.parse.settings.read":         true,
+	"user.webhooks.parse.settings.update":       true,
+	"user.webhooks.parse.stats.read":            true,
+	"validations.email.create":                  true,
+	"validations.email.read":                    true,
+	"whitelabel.create":                         true,
+	"whitelabel.delete":                         true,
+	"whitelabel.read":                           true,
+	"whitelabel.update":                         true,
+}
+
+// sendgridAutomaticScopes are scopes that SendGrid sets automatically and should not be included in user input
+var sendgridAutomaticScopes = map[string]bool{
+	"2fa_exempt":                 true,
+	"2fa_required":               true,
+	"sender_verification_legacy": true, // SendGrid manages this scope automatically
+}
+
+// apiKeyAutomaticScopes are the scopes API keys get without asking: the provider adds sender_verification_eligible
+// to the keys it creates, and SendGrid adds 2fa_required.
+var apiKeyAutomaticScopes = map[string]bool{
+	"2fa_required":                 true,
+	"sender_verification_eligible": true,
+}
+
+// scopeCatalog is the set of the valid scopes, and of the ones the API key of the provider can grant.
+type scopeCatalog struct {
+	valid map[string]bool
+	// grantable are the scopes of the API key of the provider, nil when they couldn't be retrieved.
+	grantable map[string]bool
+}
+
+// newScopeCatalog returns the embedded catalog, completed with the scopes of the API key of the provider
+// when they're known, since SendGrid may have added scopes after the embedded catalog was retrieved.
+func newScopeCatalog(grantable []string) *scopeCatalog {
+	catalog := &scopeCatalog{valid: make(map[string]bool, len(validSendgridScopes)+len(grantable))}
+	for scope := range validSendgridScopes {
+		catalog.valid[scope] = true
+	}
+
+	if grantable == nil {
+		return catalog
+	}
+
+	catalog.grantable = make(map[string]bool, len(grantable))
+	for _, scope := range grantable {
+		catalog.valid[scope] = true
+		catalog.grantable[scope] = true
+	}
+
+	return catalog
+}
+
+// scopeCatalog returns the scope catalog of the API key of the provider, acting on behalf of the given subuser.
+// The scopes are retrieved from SendGrid once per run, the embedded catalog is used when they can't be.
+func (c *Config) scopeCatalog(ctx context.Context, onBehalfOf string) *scopeCatalog {
+	c.scopesMutex.Lock()
+	defer c.scopesMutex.Unlock()
+
+	if catalog, ok := c.scopeCatalogs[onBehalfOf]; ok {
+		return catalog
+	}
+
+	catalog := newScopeCatalog(nil)
+
+	if c.RefreshScopes {
+		client := c.NewClient(onBehalfOf)
+
+		// The call isn't made for a resource, the default timeout of the retries applies.
+		scopesStruct, err := sendgrid.RetryOnRateLimit(ctx, &schema.ResourceData{}, func() (interface{}, sendgrid.RequestError) {
+			return client.ReadScopes(ctx)
+		})
+		if err != nil {
+			tflog.Warn(ctx, "Could not retrieve the scopes of the API key, using the embedded scope catalog", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else {
+			catalog = newScopeCatalog(scopesStruct.([]string))
+		}
+	}
+
+	if c.scopeCatalogs == nil {
+		c.scopeCatalogs = map[string]*scopeCatalog{}
+	}
+
+	c.scopeCatalogs[onBehalfOf] = catalog
+
+	return catalog
+}
+
+// scopeProblems are the scopes which can't be assigned, by reason.
+type scopeProblems struct {
+	automatic   []string
+	invalid     []string
+	ungrantable []string
+}
+
+// check returns the scopes which can't be assigned: the automatic ones, the ones which aren't valid,
+// and the ones the API key of the provider can't grant, since it doesn't have them.
+func (c *scopeCatalog) check(scopes []string, automatic map[string]bool) scopeProblems {
+	var problems scopeProblems
+
+	for _, scope := range scopes {
+		switch {
+		case automatic[scope]:
+			problems.automatic = append(problems.automatic, scope)
+		case !c.valid[scope]:
+			problems.invalid = append(problems.invalid, scope)
+		case c.grantable != nil && !c.grantable[scope]:
+			problems.ungrantable = append(problems.ungrantable, scope)
+		}
+	}
+
+	sort.Strings(problems.automatic)
+	sort.Strings(problems.invalid)
+	sort.Strings(problems.ungrantable)
+
+	return problems
+}
+
+func (p scopeProblems) diagnostics(path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(p.automatic) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Automatic scopes cannot be manually assigned",
+			Detail: fmt.Sprintf(
+				"the following scopes are set automatically by SendGrid and cannot be manually assigned: %s",
+				strings.Join(p.automatic, ", "),
+			),
+			AttributePath: path,
+		})
+	}
+
+	if len(p.invalid) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid or unassignable scopes",
+			Detail: fmt.Sprintf(
+				"the following scopes are not valid or assignable: %s. Please check the SendGrid API documentation for valid scopes",
+				strings.Join(p.invalid, ", "),
+			),
+			AttributePath: path,
+		})
+	}
+
+	if len(p.ungrantable) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Scopes the API key of the provider cannot grant",
+			Detail: fmt.Sprintf(
+				"the API key of the provider doesn't have the following scopes, so it cannot grant them: %s",
+				strings.Join(p.ungrantable, ", "),
+			),
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// err returns the problems as an error, for the diffs which can't return diagnostics.
+func (p scopeProblems) err() error {
+	var errs []error
+	for _, d := range p.diagnostics(nil) {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary, d.Detail))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateScopes validates the scopes of a set against a catalog.
+func validateScopes(catalog *scopeCatalog, v interface{}, automatic map[string]bool, path cty.Path) diag.Diagnostics {
+	return catalog.check(scopesFromSet(v.(*schema.Set)), automatic).diagnostics(path)
+}
+
+func scopesFromSet(set *schema.Set) []string {
+	scopes := make([]string, 0, set.Len())
+	for _, scope := range set.List() {
+		scopes = append(scopes, scope.(string))
+	}
+
+	return scopes
+}
+
+// removeScopes returns the scopes which aren't in removed.
+func removeScopes(scopes []string, removed map[string]bool) []string {
+	var kept []string
+	for _, scope := range scopes {
+		if !removed[scope] {
+			kept = append(kept, scope)
+		}
+	}
+
+	return kept
+}
+
+// scopePreset is a named set of scope patterns.
+type scopePreset struct {
+	include []string
+	exclude []string
+}
+
+// scopePresets are the presets of scope_presets, expanded like scope_patterns.
+var scopePresets = map[string]scopePreset{
+	"mail_send_only":            {include: []string{"mail.send"}},
+	"read_only":                 {include: []string{"*.read"}},
+	"billing_admin":             {include: []string{"billing.*"}},
+	"full_access_minus_billing": {include: []string{"*"}, exclude: []string{"billing.*"}},
+}
+
+func scopePresetNames() []string {
+	names := make([]string, 0, len(scopePresets))
+	for name := range scopePresets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// validateScopePattern validates a glob pattern of scope_patterns, e.g. templates.* or *.read.
+func validateScopePattern(v interface{}, k string) ([]string, []error) {
+	if _, err := path.Match(v.(string), ""); err != nil {
+		return nil, []error{fmt.Errorf("%q: %q is not a valid glob pattern: %w", k, v, err)}
+	}
+
+	return nil, nil
+}
+
+// expand returns the scopes matching the presets and the patterns, except the excluded ones.
+// The patterns are expanded against the scopes the API key of the provider can grant when they're known,
+// the embedded catalog otherwise.
+func (c *scopeCatalog) expand(presets, patterns []string, excluded map[string]bool) ([]string, error) {
+	candidates := c.grantable
+	if candidates == nil {
+		candidates = c.valid
+	}
+
+	expanded := map[string]bool{}
+
+	expandPatterns := func(origin string, include, exclude []string) error {
+		for _, pattern := range include {
+			matched := false
+
+			for scope := range candidates {
+				if excluded[scope] || !scopeMatches(scope, []string{pattern}) || scopeMatches(scope, exclude) {
+					continue
+				}
+
+				expanded[scope] = true
+				matched = true
+			}
+
+			if !matched {
+				return fmt.Errorf("%w: %s %q", ErrScopePatternNoMatch, origin, pattern)
+			}
+		}
+
+		return nil
+	}
+
+	for _, name := range presets {
+		preset, ok := scopePresets[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownScopePreset, name)
+		}
+
+		if err := expandPatterns("scope preset "+name+" pattern", preset.include, preset.exclude); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expandPatterns("scope pattern", patterns, nil); err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(expanded))
+	for scope := range expanded {
+		scopes = append(scopes, scope)
+	}
+
+	sort.Strings(scopes)
+
+	return scopes, nil
+}
+
+func scopeMatches(scope string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, scope); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func scopePresetsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeSet,
+		Description: "Presets whose scopes are added to `scopes`: " + strings.Join(scopePresetNames(), ", ") + ". " +
+			"They're expanded at plan time, so the plan shows the exact scopes which are granted.",
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(scopePresetNames(), false),
+		},
+	}
+}
+
+func scopePatternsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeSet,
+		Description: "Glob patterns, e.g. `templates.*` or `*.read`, whose matching scopes are added to `scopes`. " +
+			"They're expanded at plan time against the scopes the API key of the provider has, " +
+			"or the embedded scope catalog when `refresh_scopes` is false.",
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateScopePattern,
+		},
+	}
+}
+
+// expandScopesDiff plans the scopes of a resource using scope_presets or scope_patterns:
+// the scopes of its configuration, with the ones the presets and the patterns expand to, and the extra ones.
+// It returns whether the scopes were expanded.
+func expandScopesDiff(
+	diff *schema.ResourceDiff,
+	catalog *scopeCatalog,
+	excluded map[string]bool,
+	extra []string,
+) (bool, error) {
+	if !diff.NewValueKnown("scope_presets") || !diff.NewValueKnown("scope_patterns") {
+		return true, diff.SetNewComputed("scopes")
+	}
+
+	presets := diff.Get("scope_presets").(*schema.Set)
+	patterns := diff.Get("scope_patterns").(*schema.Set)
+
+	if presets.Len() == 0 && patterns.Len() == 0 {
+		return false, nil
+	}
+
+	if !diff.NewValueKnown("scopes") {
+		return true, diff.SetNewComputed("scopes")
+	}
+
+	expanded, err := catalog.expand(scopesFromSet(presets), scopesFromSet(patterns), excluded)
+	if err != nil {
+		return false, err
+	}
+
+	scopes, _ := configuredScopes(diff)
+	scopes = append(scopes, expanded...)
+	scopes = append(scopes, extra...)
+
+	return true, diff.SetNew("scopes", scopes)
+}
+
+// configuredScopes returns the scopes of the configuration, rather than the planned ones,
+// which are the scopes of the state when they're not configured, and whether they're configured.
+func configuredScopes(diff *schema.ResourceDiff) ([]string, bool) {
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil, false
+	}
+
+	value := config.GetAttr("scopes")
+	if value.IsNull() {
+		return nil, false
+	}
+
+	if !value.IsWhollyKnown() {
+		return nil, true
+	}
+
+	var scopes []string
+	for it := value.ElementIterator(); it.Next(); {
+		_, scope := it.Element()
+		scopes = append(scopes, scope.AsString())
+	}
+
+	return scopes, true
+}
@@ -0,0 +1,140 @@
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseIdPUsers(t *testing.T) {
+	want := []idpUser{
+		{Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace", Groups: []string{"developers", "admins"}},
+		{Email: "grace@example.com", FirstName: "Grace", LastName: "Hopper"},
+	}
+
+	fromJSON, err := parseIdPUsers(`[
+		{"email": "ada@example.com", "first_name": "Ada", "last_name": "Lovelace", "groups": ["developers", "admins"]},
+		{"email": "grace@example.com", "first_name": "Grace", "last_name": "Hopper"}
+	]`, "json")
+	if err != nil || !reflect.DeepEqual(fromJSON, want) {
+		t.Errorf("json users = %+v (%v), want %+v", fromJSON, err, want)
+	}
+
+	fromCSV, err := parseIdPUsers("Email,First_Name,Last_Name,Groups\n"+
+		"ada@example.com,Ada,Lovelace,developers; admins\n"+
+		"grace@example.com,Grace,Hopper,\n", "csv")
+	if err != nil || !reflect.DeepEqual(fromCSV, want) {
+		t.Errorf("csv users = %+v (%v), want %+v", fromCSV, err, want)
+	}
+
+	if _, err := parseIdPUsers("first_name\nAda\n", "csv"); !errors.Is(err, ErrSSOMappingCSVHeader) {
+		t.Errorf("expected ErrSSOMappingCSVHeader, got %v", err)
+	}
+
+	if _, err := parseIdPUsers(`[{"email": "ada@example.com"}, {"email": "ada@example.com"}]`, "json"); !errors.Is(err, ErrSSOMappingDuplicateUser) {
+		t.Errorf("expected ErrSSOMappingDuplicateUser, got %v", err)
+	}
+}
+
+func TestMapIdPUsers(t *testing.T) {
+	users := []idpUser{
+		{Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace", Groups: []string{"developers", "support"}},
+		{Email: "grace@example.com", FirstName: "Grace", LastName: "Hopper", Groups: []string{"developers", "admins"}},
+		{Email: "alan@example.com", FirstName: "Alan", LastName: "Turing", Groups: []string{"marketing"}},
+	}
+	rules := []ssoGroupRule{
+		{Group: "developers", Scopes: []string{"mail.send", "templates.read"}},
+		{Group: "support", Scopes: []string{"stats.read", "mail.send"}},
+		{Group: "admins", IsAdmin: true},
+	}
+
+	specs, err := mapIdPUsers(users, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]teammateSpec{
+		"ada@example.com": {
+			Email: "ada@example.com", IsSSO: true, FirstName: "Ada", LastName: "Lovelace",
+			Scopes: []string{"mail.send", "stats.read", "templates.read"},
+		},
+		"grace@example.com": {Email: "grace@example.com", IsAdmin: true, IsSSO: true, FirstName: "Grace", LastName: "Hopper"},
+	}
+	if !reflect.DeepEqual(specs, want) {
+		t.Errorf("specs = %+v, want %+v", specs, want)
+	}
+
+	nameless := []idpUser{{Email: "ada@example.com", Groups: []string{"developers"}}}
+	if _, err := mapIdPUsers(nameless, rules); !errors.Is(err, ErrSSOMappingUserNames) {
+		t.Errorf("expected ErrSSOMappingUserNames, got %v", err)
+	}
+}
+
+func TestSSOTeammateMappingDiff(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(file, []byte("email,first_name,last_name,groups\nada@example.com,Ada,Lovelace,developers\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"users_file": file,
+		"group_rule": []interface{}{
+			map[string]interface{}{"group": "developers", "scope_presets": []interface{}{"mail_send_only"}},
+		},
+	})
+
+	diff, err := resourceSendgridSSOTeammateMapping().Diff(context.Background(), nil, config, &Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := diff.Attributes["teammate.#"]; got == nil || got.New != "1" {
+		t.Errorf("teammate.# = %+v, want the teammate of ada", got)
+	}
+
+	adminWithScopes := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"users":      `[]`,
+		"group_rule": []interface{}{map[string]interface{}{"group": "admins", "is_admin": true, "scopes": []interface{}{"mail.send"}}},
+	})
+
+	if _, err := resourceSendgridSSOTeammateMapping().Diff(context.Background(), nil, adminWithScopes, &Config{}); !errors.Is(err, ErrTeammateAdminScopes) {
+		t.Errorf("expected ErrTeammateAdminScopes, got %v", err)
+	}
+}
+
+func TestSSOTeammateMappingDiffNoUsers(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(file, []byte("email,first_name,last_name,groups\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []interface{}{
+		map[string]interface{}{"group": "developers", "scope_presets": []interface{}{"mail_send_only"}},
+	}
+
+	for name, users := range map[string]map[string]interface{}{
+		"empty json":  {"users": `[]`},
+		"header only": {"users_file": file},
+	} {
+		users["group_rule"] = rules
+
+		if _, err := resourceSendgridSSOTeammateMapping().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(users), &Config{}); !errors.Is(err, ErrSSOMappingNoUsers) {
+			t.Errorf("%s: expected ErrSSOMappingNoUsers, got %v", name, err)
+		}
+	}
+
+	allowed := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"users":            `[]`,
+		"allow_remove_all": true,
+		"group_rule":       rules,
+	})
+
+	if _, err := resourceSendgridSSOTeammateMapping().Diff(context.Background(), nil, allowed, &Config{}); err != nil {
+		t.Errorf("expected no error with allow_remove_all, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package sendgrid
+
+import (
+	"testing"
+	"time"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+)
+
+func TestInviteNeedsResend(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name           string
+		expirationDate string
+		before         time.Duration
+		want           bool
+	}{
+		{name: "far from expiration", expirationDate: now.Add(3 * day).Format(time.RFC3339), before: day},
+		{name: "within the resend window", expirationDate: now.Add(12 * time.Hour).Format(time.RFC3339), before: day, want: true},
+		{name: "expired", expirationDate: now.Add(-day).Format(time.RFC3339), want: true},
+		{name: "unknown expiration", expirationDate: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inviteNeedsResend(tt.expirationDate, tt.before, now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInviteExpirationDate(t *testing.T) {
+	pending := &sendgrid.User{UserType: "pending", ExpirationDate: 1704067200}
+	if got := inviteExpirationDate(pending); got != "2024-01-01T00:00:00Z" {
+		t.Errorf("expected the expiration of the pending user, got %q", got)
+	}
+
+	active := &sendgrid.User{UserType: "teammate", ExpirationDate: 1704067200}
+	if got := inviteExpirationDate(active); got != "" {
+		t.Errorf("expected no expiration for an active user, got %q", got)
+	}
+}
+
+func TestSetInviteResendDue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d := resourceSendgridTeammate().TestResourceData()
+	//nolint:errcheck
+	d.Set("user_status", "pending")
+	//nolint:errcheck
+	d.Set("resend_invite", true)
+	//nolint:errcheck
+	d.Set("resend_invite_before", "24h")
+	//nolint:errcheck
+	d.Set("expiration_date", now.Add(12*time.Hour).Format(time.RFC3339))
+
+	setInviteResendDue(d, now.Add(-48*time.Hour))
+
+	if d.Get("invite_resend_due").(bool) {
+		t.Error("expected the invitation not to be due two days before the resend window")
+	}
+
+	setInviteResendDue(d, now)
+
+	if !d.Get("invite_resend_due").(bool) {
+		t.Error("expected the invitation expiring within resend_invite_before to be due")
+	}
+
+	//nolint:errcheck
+	d.Set("resend_invite", false)
+	setInviteResendDue(d, now)
+
+	if d.Get("invite_resend_due").(bool) {
+		t.Error("expected the invitation not to be due without resend_invite")
+	}
+}
@@ -0,0 +1,199 @@
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPlanTeammates(t *testing.T) {
+	current := map[string]teammateSpec{
+		"ada@example.com":   {Email: "ada@example.com", Scopes: []string{"mail.send"}},
+		"grace@example.com": {Email: "grace@example.com", IsAdmin: true},
+		"alan@example.com":  {Email: "alan@example.com"},
+	}
+	desired := map[string]teammateSpec{
+		"ada@example.com":   {Email: "ada@example.com", Scopes: []string{"mail.send", "stats.read"}},
+		"grace@example.com": {Email: "grace@example.com", IsAdmin: true},
+		"linus@example.com": {Email: "linus@example.com"},
+	}
+
+	plan := planTeammates(current, desired)
+
+	emails := func(specs []teammateSpec) []string {
+		var emails []string
+		for _, spec := range specs {
+			emails = append(emails, spec.Email)
+		}
+
+		return emails
+	}
+
+	if got := emails(plan.invite); !reflect.DeepEqual(got, []string{"linus@example.com"}) {
+		t.Errorf("invite = %v, want linus", got)
+	}
+
+	if got := emails(plan.update); !reflect.DeepEqual(got, []string{"ada@example.com"}) {
+		t.Errorf("update = %v, want ada", got)
+	}
+
+	if got := emails(plan.remove); !reflect.DeepEqual(got, []string{"alan@example.com"}) {
+		t.Errorf("remove = %v, want alan", got)
+	}
+}
+
+func TestReadTeammates(t *testing.T) {
+	var (
+		requests []string
+		mutex    sync.Mutex
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.URL.Path)
+		mutex.Unlock()
+
+		switch r.URL.Path {
+		case "/teammates":
+			fmt.Fprint(w, `{"result": [
+				{"username": "ada", "email": "ada@example.com"},
+				{"username": "grace", "email": "grace@example.com", "is_admin": true},
+				{"username": "other", "email": "other@example.com"}
+			]}`)
+		case "/teammates/pending":
+			fmt.Fprint(w, `{"result": [{"email": "linus@example.com", "token": "abc", "scopes": ["mail.send"]}]}`)
+		case "/teammates/ada":
+			fmt.Fprint(w, `{"username": "ada", "email": "ada@example.com", "scopes": ["stats.read", "mail.send", "2fa_required"]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{APIKey: "test-api-key", Host: server.URL}
+	d := resourceSendgridTeammates().TestResourceData()
+
+	known := map[string]teammateSpec{
+		"ada@example.com":   {Email: "ada@example.com"},
+		"grace@example.com": {Email: "grace@example.com"},
+		"linus@example.com": {Email: "linus@example.com"},
+		"alan@example.com":  {Email: "alan@example.com"},
+	}
+
+	state, err := readTeammates(context.Background(), d, config.NewClient(""), known, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]teammateSpec{
+		"ada@example.com":   {Email: "ada@example.com", Scopes: []string{"mail.send", "stats.read"}},
+		"grace@example.com": {Email: "grace@example.com", IsAdmin: true},
+		"linus@example.com": {Email: "linus@example.com", Scopes: []string{"mail.send"}},
+	}
+
+	for email, spec := range want {
+		if !state.specs[email].equal(spec) {
+			t.Errorf("%s = %+v, want %+v", email, state.specs[email], spec)
+		}
+	}
+
+	// The teammates which aren't found are removed from the state, to be invited again.
+	if _, ok := state.specs["alan@example.com"]; ok || len(state.specs) != len(want) {
+		t.Errorf("specs = %+v, want the teammates found only", state.specs)
+	}
+
+	wantStatus := map[string]string{
+		"ada@example.com":   "active",
+		"grace@example.com": "active",
+		"linus@example.com": "pending",
+	}
+	if !reflect.DeepEqual(state.status, wantStatus) {
+		t.Errorf("status = %v, want %v", state.status, wantStatus)
+	}
+
+	// One listing of each kind, and a read of the active teammate which isn't an admin.
+	if len(requests) != 3 {
+		t.Errorf("requested %v, want the two listings and the read of ada", requests)
+	}
+}
+
+func TestApplyTeammatesListsOnce(t *testing.T) {
+	var (
+		listings int
+		deleted  []string
+		mutex    sync.Mutex
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/teammates":
+			listings++
+
+			fmt.Fprint(w, `{"result": [
+				{"username": "ada", "email": "ada@example.com"},
+				{"username": "alan", "email": "alan@example.com"},
+				{"username": "grace", "email": "grace@example.com"}
+			]}`)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/teammates/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/teammates/"))
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{APIKey: "test-api-key", Host: server.URL}
+	d := resourceSendgridTeammates().TestResourceData()
+
+	current := map[string]teammateSpec{
+		"ada@example.com":   {Email: "ada@example.com"},
+		"alan@example.com":  {Email: "alan@example.com"},
+		"grace@example.com": {Email: "grace@example.com"},
+	}
+
+	notRemoved, err := applyTeammates(context.Background(), d, config.NewClient(""), planTeammates(current, nil), nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(notRemoved) != 0 || len(deleted) != 3 {
+		t.Errorf("deleted %v, not removed %v, want the three teammates deleted", deleted, notRemoved)
+	}
+
+	// The concurrent removals share the usernames listed before the batches.
+	if listings != 1 {
+		t.Errorf("listed the teammates %d times, want once", listings)
+	}
+}
+
+func TestTeammatesDiffDuplicateEmail(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"teammate": []interface{}{
+			map[string]interface{}{"email": "ada@example.com", "scopes": []interface{}{"mail.send"}},
+			map[string]interface{}{"email": "Ada@example.com", "scopes": []interface{}{"stats.read"}},
+			map[string]interface{}{"email": "grace@example.com", "scopes": []interface{}{"mail.send"}},
+		},
+	})
+
+	_, err := resourceSendgridTeammates().Diff(context.Background(), nil, config, &Config{})
+	if !errors.Is(err, ErrTeammateDuplicateEmail) {
+		t.Fatalf("expected ErrTeammateDuplicateEmail, got %v", err)
+	}
+
+	if strings.Contains(err.Error(), "grace@example.com") {
+		t.Errorf("expected only the duplicate email to be reported, got %v", err)
+	}
+}
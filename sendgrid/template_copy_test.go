@@ -0,0 +1,37 @@
+package sendgrid
+
+import (
+	"reflect"
+	"testing"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+)
+
+func TestTemplateCopyChanges(t *testing.T) {
+	sourceVersions := []sendgrid.TemplateVersion{
+		{ID: "s-1", UpdatedAt: "2024-01-02 10:00:00"},
+		{ID: "s-2", UpdatedAt: "2024-01-03 10:00:00"},
+		{ID: "s-3", UpdatedAt: "2024-01-04 10:00:00"},
+	}
+	versionIDs := map[string]interface{}{"s-1": "t-1", "s-2": "t-2", "s-4": "t-4"}
+	copiedVersions := map[string]interface{}{
+		"s-1": "2024-01-02 10:00:00",
+		"s-2": "2023-12-31 10:00:00",
+		"s-4": "2023-12-31 10:00:00",
+	}
+
+	copies, deletes := templateCopyChanges(sourceVersions, versionIDs, copiedVersions)
+
+	if want := []string{"s-2", "s-3"}; !reflect.DeepEqual(copies, want) {
+		t.Errorf("copies: expected %v, got %v", want, copies)
+	}
+
+	if want := []string{"s-4"}; !reflect.DeepEqual(deletes, want) {
+		t.Errorf("deletes: expected %v, got %v", want, deletes)
+	}
+
+	active := &sendgrid.Template{Versions: []sendgrid.TemplateVersion{{ID: "s-1"}, {ID: "s-2", Active: 1}}}
+	if got := templateCopyActiveVersionID(active, versionIDs); got != "t-2" {
+		t.Errorf("active version: expected %q, got %q", "t-2", got)
+	}
+}
@@ -0,0 +1,60 @@
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSendgridTemplateRenderOffline(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSendgridTemplateRender().Schema, map[string]interface{}{
+		"subject":       "Hello {{first_name}}",
+		"html_content":  "<p>{{#each items}}{{name}}{{#unless @last}}, {{/unless}}{{/each}}</p>",
+		"plain_content": `{{insert last_name "default=Customer"}}`,
+		"test_data":     `{"first_name": "Ada & Co", "items": [{"name": "Book"}, {"name": "Pen"}]}`,
+	})
+
+	if diags := dataSendgridTemplateRenderRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for key, want := range map[string]string{
+		"rendered_subject":       "Hello Ada &amp; Co",
+		"rendered_html":          "<p>Book, Pen</p>",
+		"rendered_plain_content": "Customer",
+	} {
+		if got := d.Get(key).(string); got != want {
+			t.Errorf("%s: expected %q, got %q", key, want, got)
+		}
+	}
+
+	if d.Id() == "" {
+		t.Error("expected the ID to be set")
+	}
+
+	//nolint:errcheck
+	d.Set("html_content", "{{#if a}}")
+
+	if diags := dataSendgridTemplateRenderRead(context.Background(), d, nil); !diags.HasError() {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestReadTemplateVersionToRenderWithoutActiveVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "d-1", "name": "welcome", "generation": "dynamic", "versions": [{"id": "v-1", "active": 0}]}`)
+	}))
+	defer server.Close()
+
+	config := &Config{APIKey: "test-api-key", Host: server.URL}
+	d := dataSendgridTemplateRender().TestResourceData()
+
+	if _, err := readTemplateVersionToRender(context.Background(), d, config, "d-1"); !errors.Is(err, ErrTemplateNoActiveVersion) {
+		t.Errorf("expected ErrTemplateNoActiveVersion, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package sendgrid
+
+import (
+	"reflect"
+	"testing"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+)
+
+func TestTemplateTestSendMail(t *testing.T) {
+	version := &sendgrid.TemplateVersion{
+		ID:           "v-1",
+		Active:       1,
+		Subject:      "Hello {{first_name}}",
+		HTMLContent:  "<p>Hello {{first_name}}</p>",
+		PlainContent: "Hello {{first_name}}",
+	}
+
+	mail, renderedBy, err := templateTestSendMail("d-1", version, `{"first_name": "Ada"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if renderedBy != "sendgrid" || mail.TemplateID != "d-1" || len(mail.Content) != 0 {
+		t.Errorf("expected the active version to be sent by template ID, got %q %+v", renderedBy, mail)
+	}
+
+	version.Active = 0
+
+	mail, renderedBy, err = templateTestSendMail("d-1", version, `{"first_name": "Ada"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sendgrid.MailContent{{Type: "text/plain", Value: "Hello Ada"}, {Type: "text/html", Value: "<p>Hello Ada</p>"}}
+	if renderedBy != "provider" || mail.TemplateID != "" || mail.Subject != "Hello Ada" || !reflect.DeepEqual(mail.Content, want) {
+		t.Errorf("expected an inactive version to be rendered by the provider, got %q %+v", renderedBy, mail)
+	}
+
+	version.HTMLContent = "{{#if a}}"
+	if _, _, err := templateTestSendMail("d-1", version, ""); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
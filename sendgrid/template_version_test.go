@@ -0,0 +1,131 @@
+package sendgrid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestTemplateVersionContent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "content.html")
+	if err := os.WriteFile(file, []byte("<p>from file</p>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{
+		"html_content":       "<p>inline</p>",
+		"html_content_file":  "",
+		"plain_content":      "",
+		"plain_content_file": file,
+	}
+	get := func(key string) interface{} { return values[key] }
+
+	html, err := templateVersionContent(get, "html_content")
+	if err != nil || html != "<p>inline</p>" {
+		t.Errorf("expected the inline content, got %q (%v)", html, err)
+	}
+
+	plain, err := templateVersionContent(get, "plain_content")
+	if err != nil || plain != "<p>from file</p>" {
+		t.Errorf("expected the file content, got %q (%v)", plain, err)
+	}
+
+	values["html_content_file"] = filepath.Join(t.TempDir(), "missing.html")
+	if _, err := templateVersionContent(get, "html_content"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestTemplateVersionContentSHA256(t *testing.T) {
+	htmlOnly := templateVersionContentSHA256("<p>hello</p>", "hello", false)
+	if htmlOnly != templateVersionContentSHA256("<p>hello</p>", "generated by SendGrid", false) {
+		t.Error("the plain content must be ignored when it is not managed from a file")
+	}
+
+	withPlain := templateVersionContentSHA256("<p>hello</p>", "hello", true)
+	if withPlain == htmlOnly {
+		t.Error("the plain content must be hashed when it is managed from a file")
+	}
+
+	if templateVersionContentSHA256("<p>hello</p>h", "ello", true) == withPlain {
+		t.Error("the boundary between the HTML and the plain content must be hashed")
+	}
+
+	// echo -n "<p>hello</p>" | sha256sum
+	if want := "a5652be1ca864d36d25cfb54a41f384e2de1b3acf7513a925d72ed7258fdc0ae"; htmlOnly != want {
+		t.Errorf("expected %q, got %q", want, htmlOnly)
+	}
+}
+
+func TestTemplateVersionContents(t *testing.T) {
+	values := map[string]interface{}{
+		"html_content":           `<p>Hi {{name}}, see <a href="https://example.com">our site</a></p>`,
+		"html_content_file":      "",
+		"plain_content":          "custom",
+		"plain_content_file":     "",
+		"generate_plain_content": true,
+		"editor":                 "code",
+	}
+	get := func(key string) interface{} { return values[key] }
+
+	contents, err := templateVersionContents(get, true)
+	if err != nil || contents.plain != "Hi {{name}}, see our site (https://example.com)" || contents.generatedBySendGrid {
+		t.Errorf("expected the plain content to be generated locally, got %+v (%v)", contents, err)
+	}
+
+	values["generate_plain_content"] = false
+
+	if contents, _ := templateVersionContents(get, true); contents.plain != "custom" {
+		t.Errorf("expected the custom plain content, got %q", contents.plain)
+	}
+
+	values["generate_plain_content"] = true
+	values["editor"] = "design"
+
+	// html_content holds the HTML read from SendGrid, it isn't sent back when it's not configured.
+	if contents, _ := templateVersionContents(get, false); !contents.generatedBySendGrid || contents.html != "" {
+		t.Errorf("expected SendGrid to generate the plain content of a version edited in the Design Library, got %+v", contents)
+	}
+
+	if contents, _ := templateVersionContents(get, true); contents.generatedBySendGrid {
+		t.Error("expected the configured HTML content of a design version to be sent")
+	}
+}
+
+func TestTemplateVersionHTMLConfigured(t *testing.T) {
+	config := func(html cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"html_content":      html,
+			"html_content_file": cty.NullVal(cty.String),
+			"design_id":         cty.NullVal(cty.String),
+		})
+	}
+
+	if templateVersionHTMLConfigured(config(cty.NullVal(cty.String))) {
+		t.Error("expected a design version without html_content not to be configured")
+	}
+
+	if !templateVersionHTMLConfigured(config(cty.StringVal("<p>Hi</p>"))) {
+		t.Error("expected html_content to be configured")
+	}
+
+	if !templateVersionHTMLConfigured(config(cty.UnknownVal(cty.String))) {
+		t.Error("expected an unknown html_content to be configured")
+	}
+}
+
+func TestSuppressEquivalentJSON(t *testing.T) {
+	if !suppressEquivalentJSON("test_data", `{"a":1,"b":[true]}`, "{\n  \"b\": [true],\n  \"a\": 1\n}", nil) {
+		t.Error("expected reformatted JSON to be suppressed")
+	}
+
+	if suppressEquivalentJSON("test_data", `{"a":1}`, `{"a":2}`, nil) {
+		t.Error("expected a different value not to be suppressed")
+	}
+
+	if suppressEquivalentJSON("test_data", `{"a":1}`, ``, nil) {
+		t.Error("expected removing the test data not to be suppressed")
+	}
+}
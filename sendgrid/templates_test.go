@@ -0,0 +1,65 @@
+package sendgrid
+
+import (
+	"reflect"
+	"testing"
+
+	sendgrid "github.com/arslanbekov/terraform-provider-sendgrid/sdk"
+)
+
+func TestTemplatesFilter(t *testing.T) {
+	templates := []sendgrid.Template{
+		{ID: "d-1", Name: "welcome-prod", UpdatedAt: "2021-04-28 13:12:46"},
+		{ID: "d-2", Name: "welcome-staging", UpdatedAt: "2024-01-02 10:00:00"},
+		{ID: "d-3", Name: "receipt-prod", UpdatedAt: "2024-06-01T08:00:00Z"},
+		{ID: "d-4", Name: "invoice-prod", UpdatedAt: "not a date"},
+	}
+
+	for _, tc := range []struct {
+		name, nameRegex, updatedAfter string
+		want                          []string
+	}{
+		{name: "no filter", want: []string{"d-1", "d-2", "d-3", "d-4"}},
+		{name: "name", nameRegex: "-prod$", want: []string{"d-1", "d-3", "d-4"}},
+		{name: "updated after", updatedAfter: "2023-01-01T00:00:00Z", want: []string{"d-2", "d-3", "d-4"}},
+		{name: "both", nameRegex: "^welcome", updatedAfter: "2023-01-01T00:00:00Z", want: []string{"d-2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newTemplatesFilter(tc.nameRegex, tc.updatedAfter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, template := range templates {
+				if filter.match(template) {
+					got = append(got, template.ID)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := newTemplatesFilter("(", ""); err == nil {
+		t.Error("expected an error for an invalid regular expression")
+	}
+}
+
+func TestFlattenTemplateSummary(t *testing.T) {
+	summary := flattenTemplateSummary(sendgrid.Template{
+		ID:   "d-1",
+		Name: "welcome",
+		Versions: []sendgrid.TemplateVersion{
+			{ID: "v-1", Name: "blue", Active: 0},
+			{ID: "v-2", Name: "green", Active: 1, UpdatedAt: "2024-01-02 10:00:00"},
+		},
+	})
+
+	if summary["active_version_id"] != "v-2" || summary["active_version_name"] != "green" ||
+		summary["active_version_updated_at"] != "2024-01-02 10:00:00" || summary["version_count"] != 2 {
+		t.Errorf("unexpected summary %v", summary)
+	}
+}
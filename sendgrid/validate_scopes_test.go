@@ -1,13 +1,21 @@
 package sendgrid
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
-func TestValidateTeammateScopes(t *testing.T) {
+func TestValidateScopes(t *testing.T) {
 	tests := []struct {
 		name          string
 		scopes        []interface{}
@@ -59,7 +67,7 @@ func TestValidateTeammateScopes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			scopeSet := schema.NewSet(schema.HashString, tt.scopes)
 			path := cty.GetAttrPath("scopes")
-			diags := validateTeammateScopes(scopeSet, path)
+			diags := validateScopes(newScopeCatalog(nil), scopeSet, sendgridAutomaticScopes, path)
 
 			if tt.expectErrors {
 				if !diags.HasError() {
@@ -135,6 +143,180 @@ func TestSanitizeScopes(t *testing.T) {
 	}
 }
 
+func TestScopeCatalogCheck(t *testing.T) {
+	scopes := []string{"mail.send", "templates.read", "new.scope", "invalid.scope", "2fa_required"}
+
+	embedded := newScopeCatalog(nil).check(scopes, sendgridAutomaticScopes)
+	if want := (scopeProblems{
+		automatic: []string{"2fa_required"},
+		invalid:   []string{"invalid.scope", "new.scope"},
+	}); !reflect.DeepEqual(embedded, want) {
+		t.Errorf("embedded catalog: check() = %+v, want %+v", embedded, want)
+	}
+
+	// The scopes of the API key complete the embedded catalog, and are the only ones it can grant.
+	refreshed := newScopeCatalog([]string{"mail.send", "new.scope"}).check(scopes, sendgridAutomaticScopes)
+	if want := (scopeProblems{
+		automatic:   []string{"2fa_required"},
+		invalid:     []string{"invalid.scope"},
+		ungrantable: []string{"templates.read"},
+	}); !reflect.DeepEqual(refreshed, want) {
+		t.Errorf("refreshed catalog: check() = %+v, want %+v", refreshed, want)
+	}
+
+	if err := refreshed.err(); err == nil || !containsString(err.Error(), "cannot grant them: templates.read") {
+		t.Errorf("err() = %v, want the scopes the API key cannot grant", err)
+	}
+
+	if err := (scopeProblems{}).err(); err != nil {
+		t.Errorf("err() = %v, want nil", err)
+	}
+}
+
+func TestScopeCatalogExpand(t *testing.T) {
+	catalog := newScopeCatalog([]string{
+		"billing.read", "billing.update", "mail.send", "stats.read", "templates.read", "templates.versions.create", "2fa_exempt",
+	})
+
+	tests := []struct {
+		name     string
+		presets  []string
+		patterns []string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "mail send only",
+			presets:  []string{"mail_send_only"},
+			expected: []string{"mail.send"},
+		},
+		{
+			name:     "read only",
+			presets:  []string{"read_only"},
+			expected: []string{"billing.read", "stats.read", "templates.read"},
+		},
+		{
+			name:     "full access minus billing, without automatic scopes",
+			presets:  []string{"full_access_minus_billing"},
+			expected: []string{"mail.send", "stats.read", "templates.read", "templates.versions.create"},
+		},
+		{
+			name:     "presets and patterns",
+			presets:  []string{"billing_admin"},
+			patterns: []string{"templates.*"},
+			expected: []string{"billing.read", "billing.update", "templates.read", "templates.versions.create"},
+		},
+		{
+			name:     "pattern matching nothing",
+			patterns: []string{"subusers.*"},
+			err:      ErrScopePatternNoMatch,
+		},
+		{
+			name:    "unknown preset",
+			presets: []string{"everything"},
+			err:     ErrUnknownScopePreset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes, err := catalog.expand(tt.presets, tt.patterns, sendgridAutomaticScopes)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expand() error = %v, want %v", err, tt.err)
+			}
+
+			if tt.err == nil && !reflect.DeepEqual(scopes, tt.expected) {
+				t.Errorf("expand() = %v, want %v", scopes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigScopeCatalog(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		if r.URL.Path != "/scopes" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"scopes": ["mail.send"]}`)
+	}))
+	defer server.Close()
+
+	config := &Config{APIKey: "test-api-key", Host: server.URL, RefreshScopes: true}
+
+	for i := 0; i < 2; i++ {
+		catalog := config.scopeCatalog(context.Background(), "")
+		if !reflect.DeepEqual(catalog.grantable, map[string]bool{"mail.send": true}) {
+			t.Errorf("grantable = %v, want the scopes of the API key", catalog.grantable)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("the scopes were retrieved %d times, want once per run", calls)
+	}
+
+	offline := &Config{APIKey: "test-api-key", Host: server.URL}
+	if catalog := offline.scopeCatalog(context.Background(), ""); catalog.grantable != nil || calls != 1 {
+		t.Errorf("without refresh_scopes, the embedded catalog should be used without retrieving the scopes")
+	}
+}
+
+func TestTeammateSubuserAccessDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		isAdmin bool
+		access  map[string]interface{}
+		err     error
+	}{
+		{
+			name:   "restricted with scopes",
+			access: map[string]interface{}{"subuser": "eu", "permission_type": "restricted", "scopes": []interface{}{"mail.send"}},
+		},
+		{
+			name:   "admin without scopes",
+			access: map[string]interface{}{"subuser": "eu", "permission_type": "admin"},
+		},
+		{
+			name:    "admin teammate",
+			isAdmin: true,
+			access:  map[string]interface{}{"subuser": "eu", "permission_type": "admin"},
+			err:     ErrTeammateAdminSubuserAccess,
+		},
+		{
+			name:   "restricted without scopes",
+			access: map[string]interface{}{"subuser": "eu", "permission_type": "restricted"},
+			err:    ErrTeammateSubuserScopesRequired,
+		},
+		{
+			name:   "admin with scopes",
+			access: map[string]interface{}{"subuser": "eu", "permission_type": "admin", "scopes": []interface{}{"mail.send"}},
+			err:    ErrTeammateSubuserAdminScopes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"email":          "support@example.com",
+				"is_admin":       tt.isAdmin,
+				"is_sso":         true,
+				"subuser_access": []interface{}{tt.access},
+			})
+
+			_, err := resourceSendgridTeammate().Diff(context.Background(), nil, config, &Config{})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Diff() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
 // Helper function to check if a string contains a substring
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||